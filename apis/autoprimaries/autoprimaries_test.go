@@ -0,0 +1,75 @@
+package autoprimaries
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientListAutoprimaries(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/servers/localhost/autoprimaries", r.URL.Path)
+		called = true
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`[{"ip":"192.0.2.1","nameserver":"ns1.example.com.","account":"ops"}]`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	c := pdnshttp.NewClient(srv.URL, srv.Client(), nil, io.Discard)
+	client := New(c)
+
+	out, err := client.ListAutoprimaries(context.Background(), "localhost")
+	require.NoError(t, err)
+	require.True(t, called)
+	require.Equal(t, []Autoprimary{{IP: "192.0.2.1", Nameserver: "ns1.example.com.", Account: "ops"}}, out)
+}
+
+func TestClientCreateAutoprimary(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/servers/localhost/autoprimaries", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"ip":"192.0.2.1","nameserver":"ns1.example.com."}`, string(body))
+		called = true
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c := pdnshttp.NewClient(srv.URL, srv.Client(), nil, io.Discard)
+	client := New(c)
+
+	err := client.CreateAutoprimary(context.Background(), "localhost", Autoprimary{IP: "192.0.2.1", Nameserver: "ns1.example.com."})
+	require.NoError(t, err)
+	require.True(t, called)
+}
+
+func TestClientDeleteAutoprimary(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/api/v1/servers/localhost/autoprimaries/192.0.2.1/ns1.example.com.", r.URL.Path)
+		called = true
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := pdnshttp.NewClient(srv.URL, srv.Client(), nil, io.Discard)
+	client := New(c)
+
+	err := client.DeleteAutoprimary(context.Background(), "localhost", "192.0.2.1", "ns1.example.com.")
+	require.NoError(t, err)
+	require.True(t, called)
+}
@@ -0,0 +1,15 @@
+package autoprimaries
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+)
+
+func (c *client) CreateAutoprimary(ctx context.Context, serverID string, in Autoprimary) error {
+	path := fmt.Sprintf("/servers/%s/autoprimaries", url.PathEscape(serverID))
+
+	return c.httpClient.Post(ctx, path, nil, pdnshttp.WithJSONRequestBody(in))
+}
@@ -0,0 +1,17 @@
+package autoprimaries
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+func (c *client) DeleteAutoprimary(ctx context.Context, serverID string, ip string, nameserver string) error {
+	path := fmt.Sprintf("/servers/%s/autoprimaries/%s/%s",
+		url.PathEscape(serverID),
+		url.PathEscape(ip),
+		url.PathEscape(nameserver),
+	)
+
+	return c.httpClient.Delete(ctx, path, nil)
+}
@@ -0,0 +1,18 @@
+package autoprimaries
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+func (c *client) ListAutoprimaries(ctx context.Context, serverID string) ([]Autoprimary, error) {
+	out := make([]Autoprimary, 0)
+	path := fmt.Sprintf("/servers/%s/autoprimaries", url.PathEscape(serverID))
+
+	if err := c.httpClient.Get(ctx, path, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
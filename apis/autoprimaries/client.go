@@ -0,0 +1,14 @@
+package autoprimaries
+
+import "github.com/mittwald/go-powerdns/pdnshttp"
+
+type client struct {
+	httpClient *pdnshttp.Client
+}
+
+// New creates a new Autoprimaries client
+func New(hc *pdnshttp.Client) Client {
+	return &client{
+		httpClient: hc,
+	}
+}
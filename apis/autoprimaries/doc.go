@@ -0,0 +1,6 @@
+// Package autoprimaries contains a specialized client for interacting with PowerDNS' "Autoprimaries" API.
+//
+// More information
+//
+// Official API documentation: https://doc.powerdns.com/authoritative/http-api/autoprimaries.html
+package autoprimaries
@@ -0,0 +1,17 @@
+package autoprimaries
+
+import "context"
+
+// Client defines method for interacting with the PowerDNS "Autoprimaries" endpoints
+type Client interface {
+
+	// ListAutoprimaries lists all autoprimaries of a server
+	ListAutoprimaries(ctx context.Context, serverID string) ([]Autoprimary, error)
+
+	// CreateAutoprimary adds a new autoprimary to a server
+	CreateAutoprimary(ctx context.Context, serverID string, in Autoprimary) error
+
+	// DeleteAutoprimary removes an autoprimary from a server. The autoprimary is
+	// matched by IP address and nameserver.
+	DeleteAutoprimary(ctx context.Context, serverID string, ip string, nameserver string) error
+}
@@ -0,0 +1,11 @@
+package autoprimaries
+
+// Autoprimary models an autoprimary (formerly "supermaster"); secondary zones
+// are created automatically when a NOTIFY is received from one of these.
+//
+// More information: https://doc.powerdns.com/authoritative/http-api/autoprimaries.html#autoprimary
+type Autoprimary struct {
+	IP         string `json:"ip"`
+	Nameserver string `json:"nameserver"`
+	Account    string `json:"account,omitempty"`
+}
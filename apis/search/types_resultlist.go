@@ -1,5 +1,7 @@
 package search
 
+import "github.com/mittwald/go-powerdns/dnsname"
+
 // ResultList represents a list of search results. The type itself offers some
 // advanced filtering functions for convenience.
 type ResultList []Result
@@ -20,6 +22,27 @@ func (l ResultList) FilterByRecordType(t string) ResultList {
 	})
 }
 
+// FilterByName returns all elements of a result list that have a certain
+// name. Names are compared as domain names (see dnsname.Equal), so that case
+// and trailing dots do not matter.
+func (l ResultList) FilterByName(name string) ResultList {
+	return l.FilterBy(func(r *Result) bool {
+		return dnsname.Equal(r.Name, name)
+	})
+}
+
+// FilterByZone returns all elements of a result list that belong to a certain
+// zone; for zone results, that is the zone itself.
+func (l ResultList) FilterByZone(zone string) ResultList {
+	return l.FilterBy(func(r *Result) bool {
+		if r.ObjectType == ObjectTypeZone {
+			return dnsname.Equal(r.Name, zone)
+		}
+
+		return dnsname.Equal(r.Zone, zone)
+	})
+}
+
 // FilterBy returns all elements of a result list that match a generic matcher
 // function. The "matcher" function will be invoked for each element in the
 // result list; if it returns true, the respective item will be included in the
@@ -43,3 +43,25 @@ func TestResultListFilterByRecordTypeFiltersCorrectly(t *testing.T) {
 	assert.Equal(t, ObjectTypeRecord, filtered[0].ObjectType)
 	assert.Equal(t, ObjectTypeRecord, filtered[1].ObjectType)
 }
+
+func TestResultListFilterByNameComparesDomainNames(t *testing.T) {
+	out := make(ResultList, 0)
+	err := json.Unmarshal([]byte(exampleSearchResult), &out)
+
+	require.Nil(t, err)
+
+	filtered := out.FilterByName("Example-Search.DE")
+
+	assert.Len(t, filtered, 5)
+	assert.Len(t, out.FilterByName("www.example-search.de."), 0)
+}
+
+func TestResultListFilterByZoneFiltersCorrectly(t *testing.T) {
+	out := make(ResultList, 0)
+	err := json.Unmarshal([]byte(exampleSearchResult), &out)
+
+	require.Nil(t, err)
+
+	assert.Len(t, out.FilterByZone("example-search.de"), 5)
+	assert.Len(t, out.FilterByZone("example.com."), 0)
+}
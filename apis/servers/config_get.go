@@ -0,0 +1,19 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+func (c *client) GetConfigSetting(ctx context.Context, serverID string, name string) (*ConfigSetting, error) {
+	setting := ConfigSetting{}
+	path := fmt.Sprintf("/servers/%s/config/%s", url.PathEscape(serverID), url.PathEscape(name))
+
+	err := c.httpClient.Get(ctx, path, &setting)
+	if err != nil {
+		return nil, err
+	}
+
+	return &setting, nil
+}
@@ -0,0 +1,18 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+func (c *client) ListConfig(ctx context.Context, serverID string) (ConfigSettingList, error) {
+	settings := make(ConfigSettingList, 0)
+
+	err := c.httpClient.Get(ctx, fmt.Sprintf("/servers/%s/config", url.PathEscape(serverID)), &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,80 @@
+package servers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestListConfigReturnsSettings(t *testing.T) {
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/config").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`[
+			{"name": "api-rectify", "type": "ConfigSetting", "value": "yes"},
+			{"name": "default-soa-edit", "type": "ConfigSetting", "value": "INCEPTION-INCREMENT"},
+			{"name": "default-ttl", "type": "ConfigSetting", "value": "3600"},
+			{"name": "local-address", "type": "ConfigSetting", "value": "0.0.0.0, ::"}
+		]`)
+
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := pdnshttp.NewClient("http://dns.example", hc, &pdnshttp.APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+	sc := New(c)
+
+	settings, err := sc.ListConfig(context.Background(), "localhost")
+
+	require.Nil(t, err)
+	require.Len(t, settings, 4)
+	assert.True(t, gock.IsDone())
+
+	rectify, ok := settings.Get("api-rectify")
+	require.True(t, ok)
+	b, err := rectify.Bool()
+	assert.Nil(t, err)
+	assert.True(t, b)
+
+	ttl, ok := settings.Get("default-ttl")
+	require.True(t, ok)
+	i, err := ttl.Int()
+	assert.Nil(t, err)
+	assert.Equal(t, 3600, i)
+
+	addr, _ := settings.Get("local-address")
+	assert.Equal(t, []string{"0.0.0.0", "::"}, addr.List())
+
+	_, ok = settings.Get("does-not-exist")
+	assert.False(t, ok)
+
+	assert.Equal(t, "INCEPTION-INCREMENT", settings.Map()["default-soa-edit"])
+}
+
+func TestGetConfigSettingReturnsSetting(t *testing.T) {
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/config/default-soa-edit").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "default-soa-edit", "type": "ConfigSetting", "value": "INCEPTION-INCREMENT"}`)
+
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := pdnshttp.NewClient("http://dns.example", hc, &pdnshttp.APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+	sc := New(c)
+
+	setting, err := sc.GetConfigSetting(context.Background(), "localhost", "default-soa-edit")
+
+	require.Nil(t, err)
+	require.NotNil(t, setting)
+	assert.Equal(t, "INCEPTION-INCREMENT", setting.Value)
+	assert.True(t, gock.IsDone())
+}
+
+func TestConfigSettingBoolReturnsErrorOnInvalidValue(t *testing.T) {
+	_, err := ConfigSetting{Name: "foo", Value: "maybe"}.Bool()
+	assert.NotNil(t, err)
+}
@@ -11,4 +11,14 @@ type Client interface {
 	// GetServer returns a specific server. If the server with the given "serverID" does
 	// not exist, the error return value will contain a pdnshttp.ErrNotFound error (see example)
 	GetServer(ctx context.Context, serverID string) (*Server, error)
+
+	// ListConfig returns all configuration settings of a server
+	ListConfig(ctx context.Context, serverID string) (ConfigSettingList, error)
+
+	// GetConfigSetting returns a single configuration setting of a server. If the setting
+	// does not exist, the error return value will contain a pdnshttp.ErrNotFound error.
+	//
+	// Note that not all PowerDNS versions implement this endpoint; if in doubt, use
+	// ListConfig and ConfigSettingList.Get instead.
+	GetConfigSetting(ctx context.Context, serverID string, name string) (*ConfigSetting, error)
 }
@@ -0,0 +1,72 @@
+package servers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConfigSetting models a single configuration setting of a PowerDNS server.
+//
+// More information: https://doc.powerdns.com/authoritative/http-api/configsetting.html
+type ConfigSetting struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Bool interprets the setting's value as boolean, as PowerDNS does for its
+// own boolean settings ("yes"/"no", "true"/"false", "on"/"off", "1"/"0").
+func (s ConfigSetting) Bool() (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s.Value)) {
+	case "yes", "true", "on", "1":
+		return true, nil
+	case "no", "false", "off", "0", "":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("setting %s is not a boolean: %q", s.Name, s.Value)
+}
+
+// Int interprets the setting's value as integer.
+func (s ConfigSetting) Int() (int, error) {
+	v, err := strconv.Atoi(strings.TrimSpace(s.Value))
+	if err != nil {
+		return 0, fmt.Errorf("setting %s is not an integer: %w", s.Name, err)
+	}
+
+	return v, nil
+}
+
+// List interprets the setting's value as a comma- or whitespace-separated list.
+func (s ConfigSetting) List() []string {
+	return strings.FieldsFunc(s.Value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// ConfigSettingList represents a list of configuration settings.
+type ConfigSettingList []ConfigSetting
+
+// Get returns the setting with the given name. The second return value
+// indicates if the setting exists.
+func (l ConfigSettingList) Get(name string) (ConfigSetting, bool) {
+	for i := range l {
+		if l[i].Name == name {
+			return l[i], true
+		}
+	}
+
+	return ConfigSetting{}, false
+}
+
+// Map returns all settings as a map from setting name to (raw) value.
+func (l ConfigSettingList) Map() map[string]string {
+	out := make(map[string]string, len(l))
+
+	for i := range l {
+		out[l[i].Name] = l[i].Value
+	}
+
+	return out
+}
@@ -0,0 +1,14 @@
+package statistics
+
+import "github.com/mittwald/go-powerdns/pdnshttp"
+
+type client struct {
+	httpClient *pdnshttp.Client
+}
+
+// New creates a new Statistics client
+func New(hc *pdnshttp.Client) Client {
+	return &client{
+		httpClient: hc,
+	}
+}
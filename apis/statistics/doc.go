@@ -0,0 +1,6 @@
+// Package statistics contains a specialized client for interacting with PowerDNS' "Statistics" API.
+//
+// More information
+//
+// Official API documentation: https://doc.powerdns.com/authoritative/http-api/statistics.html
+package statistics
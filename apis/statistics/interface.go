@@ -0,0 +1,16 @@
+package statistics
+
+import "context"
+
+// Client defines method for interacting with the PowerDNS "Statistics" endpoints
+type Client interface {
+
+	// GetStatistics returns all statistics of a server. The result can be
+	// narrowed down using the "WithStatisticName" and "WithoutRings" options.
+	GetStatistics(ctx context.Context, serverID string, opts ...GetStatisticsOption) (StatisticList, error)
+
+	// GetStatistic returns a single statistic by name. If the server does not
+	// know a statistic with that name, the error return value will contain a
+	// pdnshttp.ErrNotFound error.
+	GetStatistic(ctx context.Context, serverID string, name string) (Statistic, error)
+}
@@ -0,0 +1,74 @@
+package statistics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+)
+
+type GetStatisticsOption interface {
+	ApplyToGetStatisticsRequest(req *http.Request) error
+}
+
+type getStatisticsOptionFunc func(req *http.Request) error
+
+func (g getStatisticsOptionFunc) ApplyToGetStatisticsRequest(req *http.Request) error {
+	return g(req)
+}
+
+// WithStatisticName limits the result to the statistic with the given name.
+func WithStatisticName(name string) GetStatisticsOption {
+	return getStatisticsOptionFunc(pdnshttp.WithQueryValue("statistic", name))
+}
+
+// WithoutRings omits the (potentially large) ring statistics from the result.
+func WithoutRings() GetStatisticsOption {
+	return getStatisticsOptionFunc(pdnshttp.WithQueryValue("includerings", "false"))
+}
+
+func (c *client) GetStatistics(ctx context.Context, serverID string, opts ...GetStatisticsOption) (StatisticList, error) {
+	stats := make(StatisticList, 0)
+	path := fmt.Sprintf("/servers/%s/statistics", url.PathEscape(serverID))
+
+	req, err := c.httpClient.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		if err := opt.ApplyToGetStatisticsRequest(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.httpClient.Do(ctx, req, &stats); err != nil {
+		// PowerDNS responds with 422 when an unknown statistic was requested
+		var e pdnshttp.ErrUnprocessable
+		if errors.As(err, &e) && strings.Contains(e.Message, "Unknown statistic name") {
+			return nil, pdnshttp.ErrNotFound{URL: e.URL}
+		}
+
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (c *client) GetStatistic(ctx context.Context, serverID string, name string) (Statistic, error) {
+	stats, err := c.GetStatistics(ctx, serverID, WithStatisticName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	stat := stats.Lookup(name)
+	if stat == nil {
+		return nil, pdnshttp.ErrNotFound{URL: fmt.Sprintf("/servers/%s/statistics?statistic=%s", serverID, name)}
+	}
+
+	return stat, nil
+}
@@ -0,0 +1,125 @@
+package statistics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const exampleStatistics = `[
+	{"name": "corrupt-packets", "type": "StatisticItem", "value": "0"},
+	{"name": "udp-queries", "type": "StatisticItem", "value": "1337"},
+	{"name": "response-by-qtype", "type": "MapStatisticItem", "value": [
+		{"name": "A", "value": "42"},
+		{"name": "SOA", "value": "23"}
+	]},
+	{"name": "queries", "type": "RingStatisticItem", "size": 10000, "value": [
+		{"name": "example.com/A", "value": "12"}
+	]}
+]`
+
+func TestGetStatisticsDecodesTypedValues(t *testing.T) {
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/statistics").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(exampleStatistics)
+
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := pdnshttp.NewClient("http://dns.example", hc, &pdnshttp.APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+	sc := New(c)
+
+	stats, err := sc.GetStatistics(context.Background(), "localhost")
+
+	require.Nil(t, err)
+	require.Len(t, stats, 4)
+	assert.True(t, gock.IsDone())
+
+	assert.IsType(t, StatisticItem{}, stats[0])
+	assert.IsType(t, MapStatisticItem{}, stats[2])
+	assert.IsType(t, RingStatisticItem{}, stats[3])
+
+	udp, ok := stats.Counter("udp-queries")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1337), udp)
+
+	_, ok = stats.Counter("response-by-qtype")
+	assert.False(t, ok)
+
+	_, ok = stats.Counter("does-not-exist")
+	assert.False(t, ok)
+
+	byType, ok := stats.Lookup("response-by-qtype").(MapStatisticItem)
+	require.True(t, ok)
+	a, ok := byType.Get("A")
+	assert.True(t, ok)
+	assert.Equal(t, "42", a)
+
+	ring, ok := stats.Lookup("queries").(RingStatisticItem)
+	require.True(t, ok)
+	assert.Equal(t, 10000, ring.Size)
+	assert.Len(t, ring.Value, 1)
+
+	assert.Len(t, stats.Items(), 2)
+}
+
+func TestGetStatisticsAppliesOptions(t *testing.T) {
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/statistics").
+		MatchParam("statistic", "udp-queries").
+		MatchParam("includerings", "false").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`[{"name": "udp-queries", "type": "StatisticItem", "value": "1337"}]`)
+
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := pdnshttp.NewClient("http://dns.example", hc, &pdnshttp.APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+	sc := New(c)
+
+	stats, err := sc.GetStatistics(context.Background(), "localhost", WithStatisticName("udp-queries"), WithoutRings())
+
+	require.Nil(t, err)
+	assert.Len(t, stats, 1)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGetStatisticReturnsNotFoundForUnknownStatistic(t *testing.T) {
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/statistics").
+		MatchParam("statistic", "foo").
+		Reply(http.StatusUnprocessableEntity).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"error": "Unknown statistic name"}`)
+
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := pdnshttp.NewClient("http://dns.example", hc, &pdnshttp.APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+	sc := New(c)
+
+	stat, err := sc.GetStatistic(context.Background(), "localhost", "foo")
+
+	assert.Nil(t, stat)
+	assert.True(t, pdnshttp.IsNotFound(err))
+	assert.True(t, gock.IsDone())
+}
+
+func TestStatisticListReturnsErrorOnUnknownType(t *testing.T) {
+	var out StatisticList
+
+	err := out.UnmarshalJSON([]byte(`[{"name": "foo", "type": "FooItem", "value": "1"}]`))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "FooItem")
+}
+
+func TestStatisticListReturnsErrorOnMissingType(t *testing.T) {
+	var out StatisticList
+
+	err := out.UnmarshalJSON([]byte(`[{"name": "foo", "value": "1"}]`))
+	assert.NotNil(t, err)
+	assert.Nil(t, out)
+}
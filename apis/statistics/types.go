@@ -0,0 +1,218 @@
+package statistics
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StatisticType describes which kind of statistic a Statistic value is.
+type StatisticType int
+
+// Possible statistic types; according to the PowerDNS documentation, this list
+// is exhaustive.
+const (
+	_                               = iota
+	StatisticTypeItem StatisticType = iota
+	StatisticTypeMap
+	StatisticTypeRing
+)
+
+// String makes this type implement fmt.Stringer
+func (t StatisticType) String() string {
+	switch t {
+	case StatisticTypeItem:
+		return "StatisticItem"
+	case StatisticTypeMap:
+		return "MapStatisticItem"
+	case StatisticTypeRing:
+		return "RingStatisticItem"
+	}
+
+	return ""
+}
+
+// UnmarshalJSON makes this type implement json.Unmarshaler
+func (t *StatisticType) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case `"StatisticItem"`:
+		*t = StatisticTypeItem
+	case `"MapStatisticItem"`:
+		*t = StatisticTypeMap
+	case `"RingStatisticItem"`:
+		*t = StatisticTypeRing
+	default:
+		return fmt.Errorf("unsupported statistic type: %s", string(b))
+	}
+
+	return nil
+}
+
+// Statistic is implemented by all statistic types that may be returned by the
+// statistics endpoint; use a type switch to access the concrete value.
+type Statistic interface {
+	StatisticName() string
+	StatisticType() StatisticType
+}
+
+// StatisticItem models a single, scalar statistic (like a counter).
+//
+// More information: https://doc.powerdns.com/authoritative/http-api/statistics.html#statisticitem
+type StatisticItem struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (s StatisticItem) StatisticName() string        { return s.Name }
+func (s StatisticItem) StatisticType() StatisticType { return StatisticTypeItem }
+
+// Int64 returns the statistic's value as integer. Most statistics returned by
+// PowerDNS are counters, but they are transmitted as strings.
+func (s StatisticItem) Int64() (int64, error) {
+	var v int64
+	if _, err := fmt.Sscan(s.Value, &v); err != nil {
+		return 0, fmt.Errorf("statistic %s is not numeric: %w", s.Name, err)
+	}
+
+	return v, nil
+}
+
+// SimpleStatisticItem is a name/value pair contained in map and ring statistics.
+//
+// More information: https://doc.powerdns.com/authoritative/http-api/statistics.html#simplestatisticitem
+type SimpleStatisticItem struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MapStatisticItem models a statistic that consists of a map of values.
+//
+// More information: https://doc.powerdns.com/authoritative/http-api/statistics.html#mapstatisticitem
+type MapStatisticItem struct {
+	Name  string                `json:"name"`
+	Value []SimpleStatisticItem `json:"value"`
+}
+
+func (s MapStatisticItem) StatisticName() string        { return s.Name }
+func (s MapStatisticItem) StatisticType() StatisticType { return StatisticTypeMap }
+
+// Get returns the value of a single map entry, and whether it exists.
+func (s MapStatisticItem) Get(name string) (string, bool) {
+	for i := range s.Value {
+		if s.Value[i].Name == name {
+			return s.Value[i].Value, true
+		}
+	}
+
+	return "", false
+}
+
+// RingStatisticItem models a statistic that contains the entries of a ring
+// buffer (like the most queried names).
+//
+// More information: https://doc.powerdns.com/authoritative/http-api/statistics.html#ringstatisticitem
+type RingStatisticItem struct {
+	Name  string                `json:"name"`
+	Size  int                   `json:"size"`
+	Value []SimpleStatisticItem `json:"value"`
+}
+
+func (s RingStatisticItem) StatisticName() string        { return s.Name }
+func (s RingStatisticItem) StatisticType() StatisticType { return StatisticTypeRing }
+
+// StatisticList represents a list of statistics, as returned by the statistics
+// endpoint. When decoded from JSON, each element is decoded into the concrete
+// type matching its "type" field.
+type StatisticList []Statistic
+
+// UnmarshalJSON makes this type implement json.Unmarshaler
+func (l *StatisticList) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	out := make(StatisticList, 0, len(raw))
+
+	for i := range raw {
+		var head struct {
+			Type StatisticType `json:"type"`
+		}
+
+		if err := json.Unmarshal(raw[i], &head); err != nil {
+			return err
+		}
+
+		var (
+			stat Statistic
+			err  error
+		)
+
+		switch head.Type {
+		case StatisticTypeItem:
+			s := StatisticItem{}
+			err = json.Unmarshal(raw[i], &s)
+			stat = s
+		case StatisticTypeMap:
+			s := MapStatisticItem{}
+			err = json.Unmarshal(raw[i], &s)
+			stat = s
+		case StatisticTypeRing:
+			s := RingStatisticItem{}
+			err = json.Unmarshal(raw[i], &s)
+			stat = s
+		default:
+			return fmt.Errorf("statistic at index %d has no type", i)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		out = append(out, stat)
+	}
+
+	*l = out
+	return nil
+}
+
+// Lookup returns the statistic with the given name, or nil if the list
+// contains no such statistic.
+func (l StatisticList) Lookup(name string) Statistic {
+	for i := range l {
+		if l[i].StatisticName() == name {
+			return l[i]
+		}
+	}
+
+	return nil
+}
+
+// Counter returns the numeric value of the scalar statistic with the given
+// name. The second return value is false if there is no such statistic, or if
+// it is not a numeric scalar statistic.
+func (l StatisticList) Counter(name string) (int64, bool) {
+	item, ok := l.Lookup(name).(StatisticItem)
+	if !ok {
+		return 0, false
+	}
+
+	v, err := item.Int64()
+	if err != nil {
+		return 0, false
+	}
+
+	return v, true
+}
+
+// Items returns only the scalar statistics of this list.
+func (l StatisticList) Items() []StatisticItem {
+	out := make([]StatisticItem, 0, len(l))
+
+	for i := range l {
+		if s, ok := l[i].(StatisticItem); ok {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
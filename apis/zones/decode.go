@@ -0,0 +1,71 @@
+package zones
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// errStopDecoding is returned by decoding callbacks to stop decoding early,
+// without an actual error; it is passed through to the caller.
+var errStopDecoding = errors.New("stop decoding")
+
+// decodeJSONArray decodes a JSON array element by element, by calling
+// decodeElement for each element; decodeElement is expected to decode exactly
+// one value from the decoder.
+func decodeJSONArray(dec *json.Decoder, decodeElement func(dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		if err := decodeElement(dec); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// decodeJSONObjectField decodes a JSON object, skipping all fields except for
+// the field with the given name, which is decoded by decodeField.
+func decodeJSONObjectField(dec *json.Decoder, name string, decodeField func(dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if key, ok := tok.(string); ok && key == name {
+			if err := decodeField(dec); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var skipped json.RawMessage
+		if err := dec.Decode(&skipped); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected JSON token %v, expected %v", tok, delim)
+	}
+
+	return nil
+}
@@ -0,0 +1,312 @@
+package zones
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+// ChangeAction describes what a planned change does to a record set.
+type ChangeAction int
+
+const (
+	_                         = iota
+	ActionCreate ChangeAction = iota
+	ActionReplace
+	ActionDelete
+)
+
+// String makes this type implement fmt.Stringer
+func (a ChangeAction) String() string {
+	switch a {
+	case ActionCreate:
+		return "create"
+	case ActionReplace:
+		return "replace"
+	case ActionDelete:
+		return "delete"
+	}
+
+	return ""
+}
+
+// RecordSetChange is a single change of a Plan.
+type RecordSetChange struct {
+	Action ChangeAction
+	Name   string
+	Type   string
+
+	// Desired is the desired state of the record set; nil for deletions.
+	Desired *ResourceRecordSet
+
+	// Current is the current state of the record set; nil for creations.
+	Current *ResourceRecordSet
+}
+
+// Plan is the list of changes required to turn the current record sets of a
+// zone into the desired ones. Use Diff to compute a plan.
+type Plan struct {
+	Changes []RecordSetChange
+}
+
+// Diff compares the desired record sets of a zone against its current record
+// sets (usually Zone.ResourceRecordSets from GetZone), and returns the
+// changes necessary to reach the desired state. Current record sets that are
+// not desired are deleted.
+//
+// Names and types are compared case-insensitively, and names with and without
+// trailing dot are considered equal. Records are compared by content and
+// disabled flag, regardless of their order; record contents of known types
+// are normalized (see ParseRData) before comparing. Comments are compared by
+// content and account, but only if the desired record set has non-nil
+// Comments; a nil value means that comments are not managed.
+func Diff(desired, current []ResourceRecordSet) *Plan {
+	currentIndex := make(map[string]*ResourceRecordSet, len(current))
+	for i := range current {
+		currentIndex[recordSetKey(&current[i])] = &current[i]
+	}
+
+	plan := Plan{}
+	seen := make(map[string]struct{}, len(desired))
+
+	for i := range desired {
+		d := normalizeRecordSet(desired[i])
+		key := recordSetKey(&d)
+		seen[key] = struct{}{}
+
+		c, ok := currentIndex[key]
+		if !ok {
+			plan.Changes = append(plan.Changes, RecordSetChange{Action: ActionCreate, Name: d.Name, Type: d.Type, Desired: &d})
+			continue
+		}
+
+		if !recordSetsEqual(&d, c) {
+			plan.Changes = append(plan.Changes, RecordSetChange{Action: ActionReplace, Name: d.Name, Type: d.Type, Desired: &d, Current: c})
+		}
+	}
+
+	for i := range current {
+		c := &current[i]
+		if _, ok := seen[recordSetKey(c)]; !ok {
+			plan.Changes = append(plan.Changes, RecordSetChange{Action: ActionDelete, Name: dnsname.Normalize(c.Name), Type: strings.ToUpper(c.Type), Current: c})
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		a, b := &plan.Changes[i], &plan.Changes[j]
+		if c := dnsname.Compare(a.Name, b.Name); c != 0 {
+			return c < 0
+		}
+
+		return a.Type < b.Type
+	})
+
+	return &plan
+}
+
+// IsEmpty returns true if the plan contains no changes.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the given action.
+func (p *Plan) Count(action ChangeAction) int {
+	n := 0
+
+	for i := range p.Changes {
+		if p.Changes[i].Action == action {
+			n++
+		}
+	}
+
+	return n
+}
+
+// RecordSets returns the record sets to send in a PATCH request to apply the
+// plan, with their ChangeType set accordingly.
+func (p *Plan) RecordSets() []ResourceRecordSet {
+	out := make([]ResourceRecordSet, 0, len(p.Changes))
+
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionDelete:
+			out = append(out, ResourceRecordSet{Name: c.Current.Name, Type: c.Current.Type, ChangeType: ChangeTypeDelete})
+		default:
+			set := *c.Desired
+			set.ChangeType = ChangeTypeReplace
+			out = append(out, set)
+		}
+	}
+
+	return out
+}
+
+// String renders the plan in a human-readable form, for review before it is
+// applied.
+func (p *Plan) String() string {
+	out := strings.Builder{}
+
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			fmt.Fprintf(&out, "+ %s %s (ttl %d)\n", c.Name, c.Type, c.Desired.TTL)
+			writeRecordDiff(&out, nil, c.Desired)
+		case ActionReplace:
+			fmt.Fprintf(&out, "~ %s %s\n", c.Name, c.Type)
+			if c.Desired.TTL != c.Current.TTL {
+				fmt.Fprintf(&out, "    ttl: %d -> %d\n", c.Current.TTL, c.Desired.TTL)
+			}
+			writeRecordDiff(&out, c.Current, c.Desired)
+		case ActionDelete:
+			fmt.Fprintf(&out, "- %s %s\n", c.Name, c.Type)
+			writeRecordDiff(&out, c.Current, nil)
+		}
+	}
+
+	fmt.Fprintf(&out, "Plan: %d to create, %d to replace, %d to delete.\n",
+		p.Count(ActionCreate), p.Count(ActionReplace), p.Count(ActionDelete))
+
+	return out.String()
+}
+
+func writeRecordDiff(out *strings.Builder, current, desired *ResourceRecordSet) {
+	var before, after []string
+	var beforeComments, afterComments []string
+
+	if current != nil {
+		before = recordKeys(current)
+		beforeComments = commentKeys(current.Comments)
+	}
+
+	if desired != nil {
+		after = recordKeys(desired)
+		afterComments = commentKeys(desired.Comments)
+
+		// unmanaged comments remain unchanged
+		if desired.Comments == nil {
+			afterComments = beforeComments
+		}
+	}
+
+	writeLineDiff(out, "", before, after)
+	writeLineDiff(out, "comment: ", beforeComments, afterComments)
+}
+
+func writeLineDiff(out *strings.Builder, prefix string, before, after []string) {
+	inBefore := make(map[string]struct{}, len(before))
+	for _, b := range before {
+		inBefore[b] = struct{}{}
+	}
+
+	inAfter := make(map[string]struct{}, len(after))
+	for _, a := range after {
+		inAfter[a] = struct{}{}
+	}
+
+	for _, b := range before {
+		if _, ok := inAfter[b]; !ok {
+			fmt.Fprintf(out, "    - %s%s\n", prefix, b)
+		}
+	}
+
+	for _, a := range after {
+		if _, ok := inBefore[a]; !ok {
+			fmt.Fprintf(out, "    + %s%s\n", prefix, a)
+		}
+	}
+}
+
+func recordSetKey(s *ResourceRecordSet) string {
+	return dnsname.Normalize(s.Name) + " " + strings.ToUpper(s.Type)
+}
+
+// normalizeRecordSet returns a copy of a desired record set with normalized
+// name and type. Record contents are left untouched; they are only normalized
+// for comparison (see recordKeys).
+func normalizeRecordSet(s ResourceRecordSet) ResourceRecordSet {
+	s.Name = dnsname.Normalize(s.Name)
+	s.Type = strings.ToUpper(s.Type)
+	s.ChangeType = 0
+	s.Records = append([]Record(nil), s.Records...)
+
+	return s
+}
+
+// normalizeContent converts record content into a canonical form for
+// comparison, so that equivalent contents (e.g. differing in whitespace, case
+// of names or hex digits) compare equal. Contents of types that ParseRData
+// does not support are compared verbatim.
+func normalizeContent(recordType, content string) string {
+	normalized := content
+
+	if rd, err := ParseRData(recordType, content); err == nil {
+		normalized = rd.Content()
+	}
+
+	// these types contain only numbers and names, which are case-insensitive
+	switch recordType {
+	case "CNAME", "DNAME", "NS", "PTR", "MX", "SRV", "AFSDB":
+		normalized = strings.ToLower(normalized)
+	}
+
+	return normalized
+}
+
+// recordKeys returns the normalized, sorted contents of a set's records, with
+// disabled records marked as such.
+func recordKeys(s *ResourceRecordSet) []string {
+	keys := make([]string, len(s.Records))
+
+	for i, r := range s.Records {
+		keys[i] = normalizeContent(strings.ToUpper(s.Type), r.Content)
+		if r.Disabled {
+			keys[i] += " (disabled)"
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func commentKeys(comments []Comment) []string {
+	keys := make([]string, len(comments))
+
+	for i, c := range comments {
+		keys[i] = fmt.Sprintf("%q by %q", c.Content, c.Account)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func recordSetsEqual(desired, current *ResourceRecordSet) bool {
+	if desired.TTL != current.TTL {
+		return false
+	}
+
+	if !stringSlicesEqual(recordKeys(desired), recordKeys(current)) {
+		return false
+	}
+
+	if desired.Comments != nil && !stringSlicesEqual(commentKeys(desired.Comments), commentKeys(current.Comments)) {
+		return false
+	}
+
+	return true
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,120 @@
+package zones
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func currentRecordSetsForDiff() []ResourceRecordSet {
+	return []ResourceRecordSet{
+		{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []Record{{Content: "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"}}},
+		{Name: "www.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}, {Content: "192.0.2.2"}}},
+		{Name: "example.com.", Type: "MX", TTL: 3600, Records: []Record{{Content: "10 Mail.Example.com."}}},
+		{Name: "old.example.com.", Type: "TXT", TTL: 3600, Records: []Record{{Content: `"obsolete"`}}},
+		{Name: "c.example.com.", Type: "TXT", TTL: 3600, Records: []Record{{Content: `"foo"`}}, Comments: []Comment{{Content: "old", Account: "ops", ModifiedAt: time.Unix(1, 0)}}},
+	}
+}
+
+func TestDiffReturnsEmptyPlanForEqualRecordSets(t *testing.T) {
+	desired := []ResourceRecordSet{
+		{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []Record{{Content: "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"}}},
+		{Name: "WWW.example.com", Type: "a", TTL: 300, Records: []Record{{Content: "192.0.2.2"}, {Content: "192.0.2.1"}}},
+		{Name: "example.com.", Type: "MX", TTL: 3600, Records: []Record{{Content: "10  mail.example.com."}}},
+		{Name: "old.example.com.", Type: "TXT", TTL: 3600, Records: []Record{{Content: "obsolete"}}},
+		{Name: "c.example.com.", Type: "TXT", TTL: 3600, Records: []Record{{Content: `"foo"`}}},
+	}
+
+	plan := Diff(desired, currentRecordSetsForDiff())
+
+	assert.True(t, plan.IsEmpty(), plan.String())
+}
+
+func TestDiffDetectsChanges(t *testing.T) {
+	desired := []ResourceRecordSet{
+		{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []Record{{Content: "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"}}},
+		{Name: "www.example.com.", Type: "A", TTL: 60, Records: []Record{{Content: "192.0.2.1"}, {Content: "192.0.2.3"}}},
+		{Name: "example.com.", Type: "MX", TTL: 3600, Records: []Record{{Content: "10 mail.example.com.", Disabled: true}}},
+		{Name: "new.example.com.", Type: "AAAA", TTL: 300, Records: []Record{{Content: "2001:db8::1"}}},
+		{Name: "c.example.com.", Type: "TXT", TTL: 3600, Records: []Record{{Content: `"foo"`}}, Comments: []Comment{{Content: "new", Account: "ops"}}},
+	}
+
+	plan := Diff(desired, currentRecordSetsForDiff())
+
+	require.Len(t, plan.Changes, 5)
+	assert.Equal(t, 1, plan.Count(ActionCreate))
+	assert.Equal(t, 3, plan.Count(ActionReplace))
+	assert.Equal(t, 1, plan.Count(ActionDelete))
+
+	assert.Equal(t, "example.com.", plan.Changes[0].Name)
+	assert.Equal(t, "MX", plan.Changes[0].Type)
+	assert.Equal(t, ActionReplace, plan.Changes[0].Action)
+
+	assert.Equal(t, "c.example.com.", plan.Changes[1].Name)
+	assert.Equal(t, "new.example.com.", plan.Changes[2].Name)
+	assert.Equal(t, ActionCreate, plan.Changes[2].Action)
+	assert.Equal(t, "old.example.com.", plan.Changes[3].Name)
+	assert.Equal(t, ActionDelete, plan.Changes[3].Action)
+	assert.Equal(t, "www.example.com.", plan.Changes[4].Name)
+
+	assert.Equal(t, `~ example.com. MX
+    - 10 mail.example.com.
+    + 10 mail.example.com. (disabled)
+~ c.example.com. TXT
+    - comment: "old" by "ops"
+    + comment: "new" by "ops"
++ new.example.com. AAAA (ttl 300)
+    + 2001:db8::1
+- old.example.com. TXT
+    - "obsolete"
+~ www.example.com. A
+    ttl: 300 -> 60
+    - 192.0.2.2
+    + 192.0.2.3
+Plan: 1 to create, 3 to replace, 1 to delete.
+`, plan.String())
+}
+
+func TestPlanRecordSetsSetsChangeTypes(t *testing.T) {
+	desired := []ResourceRecordSet{
+		{Name: "new.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}}},
+	}
+
+	current := []ResourceRecordSet{
+		{Name: "old.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}}},
+	}
+
+	sets := Diff(desired, current).RecordSets()
+
+	require.Len(t, sets, 2)
+	assert.Equal(t, ChangeTypeReplace, sets[0].ChangeType)
+	assert.Equal(t, "new.example.com.", sets[0].Name)
+	assert.Equal(t, ChangeTypeDelete, sets[1].ChangeType)
+	assert.Equal(t, "old.example.com.", sets[1].Name)
+
+	_, err := json.Marshal(sets)
+	assert.Nil(t, err)
+}
+
+func TestDiffKeepsDesiredRecordContents(t *testing.T) {
+	current := []ResourceRecordSet{
+		{Name: "example.com.", Type: "SPF", TTL: 3600, Records: []Record{{Content: `"v=spf1 a -all"`}}},
+		{Name: "example.com.", Type: "TXT", TTL: 3600, Records: []Record{{Content: `"a b"`}}},
+	}
+
+	desired := []ResourceRecordSet{
+		{Name: "Example.com", Type: "spf", TTL: 3600, Records: []Record{{Content: `"v=spf1  a -all"`}}},
+		{Name: "example.com.", Type: "TXT", TTL: 3600, Records: []Record{{Content: `"a  b"`}}},
+	}
+
+	sets := Diff(desired, current).RecordSets()
+
+	require.Len(t, sets, 2)
+	assert.Equal(t, "example.com.", sets[0].Name)
+	assert.Equal(t, "SPF", sets[0].Type)
+	assert.Equal(t, []Record{{Content: `"v=spf1  a -all"`}}, sets[0].Records)
+	assert.Equal(t, []Record{{Content: `"a  b"`}}, sets[1].Records)
+}
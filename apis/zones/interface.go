@@ -1,6 +1,10 @@
 package zones
 
-import "context"
+import (
+	"context"
+	"io"
+	"iter"
+)
 
 // Client defines the interface for Zone operations.
 type Client interface {
@@ -10,6 +14,19 @@ type Client interface {
 	// ListZone list known zone for a given serverID and zoneID
 	ListZone(ctx context.Context, serverID string, zoneID string) ([]Zone, error)
 
+	// ListZonesWithOptions lists known zones for a given serverID, filtered
+	// according to the given options.
+	ListZonesWithOptions(ctx context.Context, serverID string, opts ListZonesOptions) ([]Zone, error)
+
+	// IterateZones works like ListZonesWithOptions, but decodes the zones one
+	// at a time while the response is being received, instead of loading all
+	// of them into memory. Iteration stops at the first error.
+	//
+	// The response body stays open while the loop body runs; it counts as a
+	// streamed response against the client's rate limit (see
+	// pdnshttp.RateLimit.MaxInFlight), so the loop body may use the client.
+	IterateZones(ctx context.Context, serverID string, opts ListZonesOptions) iter.Seq2[Zone, error]
+
 	// CreateZone creates a new zone for a given server.
 	CreateZone(ctx context.Context, serverID string, zone Zone) (*Zone, error)
 
@@ -17,6 +34,12 @@ type Client interface {
 	// will be nil, and the error return value will be an instance of "pdnshttp.ErrNotFound".
 	GetZone(ctx context.Context, serverID string, zoneID string, opts ...GetZoneOption) (*Zone, error)
 
+	// IterateRecordSets returns the record sets of an existing zone, decoding
+	// them one at a time while the response is being received, instead of
+	// loading the entire zone into memory. Iteration stops at the first error.
+	// Like with IterateZones, the loop body may use the client.
+	IterateRecordSets(ctx context.Context, serverID string, zoneID string, opts ...GetZoneOption) iter.Seq2[ResourceRecordSet, error]
+
 	// DeleteZone deletes a zone. No shit.
 	DeleteZone(ctx context.Context, serverID string, zoneID string) error
 
@@ -27,7 +50,9 @@ type Client interface {
 	AddRecordSetToZone(ctx context.Context, serverID string, zoneID string, set ResourceRecordSet) error
 
 	// AddRecordSetsToZone will add new sets of records to a zone. Existing record sets for
-	// the exact name/type combination will be replaced.
+	// the exact name/type combination will be replaced. The comments of existing sets are
+	// only replaced if the Comments of the new set are non-nil; use an empty slice to
+	// remove them.
 	AddRecordSetsToZone(ctx context.Context, serverID string, zoneID string, sets []ResourceRecordSet) error
 
 	// RemoveRecordSetFromZone removes a record set from a zone. The record set is matched
@@ -40,15 +65,45 @@ type Client interface {
 	// by name and type.
 	RemoveRecordSetsFromZone(ctx context.Context, serverID string, zoneID string, sets []ResourceRecordSet) error
 
+	// AddRecordsToSet adds records to a record set (creating it if necessary),
+	// without replacing its existing records. Requires PowerDNS 5.0 or newer.
+	AddRecordsToSet(ctx context.Context, serverID string, zoneID string, set ResourceRecordSet) error
+
+	// RemoveRecordsFromSet removes only the given records from a record set,
+	// matched by content. Requires PowerDNS 5.0 or newer.
+	RemoveRecordsFromSet(ctx context.Context, serverID string, zoneID string, set ResourceRecordSet) error
+
+	// PatchZone applies changes to multiple record sets in a single, atomic
+	// request. Each record set's ChangeType determines how it is changed.
+	PatchZone(ctx context.Context, serverID string, zoneID string, sets []ResourceRecordSet) error
+
 	// RetrieveFromMaster retrieves a slave zone from its master
 	RetrieveFromMaster(ctx context.Context, serverID string, zoneID string) error
 
 	// NotifySlaves sends a DNS NOTIFY to all slaves
 	NotifySlaves(ctx context.Context, serverID string, zoneID string) error
 
+	// AddCommentsToRecordSet adds comments to a record set, keeping its
+	// existing comments and records. Since PowerDNS can only replace all
+	// comments of a set, this reads the current comments first; comments that
+	// are added concurrently in between may be lost.
+	AddCommentsToRecordSet(ctx context.Context, serverID string, zoneID string, name string, recordType string, comments ...Comment) error
+
+	// ReplaceRecordSetComments replaces all comments of a record set, without
+	// changing its records.
+	ReplaceRecordSetComments(ctx context.Context, serverID string, zoneID string, name string, recordType string, comments []Comment) error
+
+	// ClearRecordSetComments removes all comments of a record set, without
+	// changing its records.
+	ClearRecordSetComments(ctx context.Context, serverID string, zoneID string, name string, recordType string) error
+
 	// ExportZone exports the entire zone in AXFR format
 	ExportZone(ctx context.Context, serverID string, zoneID string) ([]byte, error)
 
+	// ExportZoneTo works like ExportZone, but writes the zone to w while it is
+	// being received, instead of buffering it in memory.
+	ExportZoneTo(ctx context.Context, serverID string, zoneID string, w io.Writer) error
+
 	// VerifyZone verifies a zone's configuration
 	VerifyZone(ctx context.Context, serverID string, zoneID string) error
 
@@ -57,4 +112,8 @@ type Client interface {
 
 	// Modifies basic zone data
 	ModifyBasicZoneData(ctx context.Context, serverID string, zoneID string, update ZoneBasicDataUpdate) error
+
+	// ImportZone creates a new zone from a master file (see ParseZoneFile).
+	// If importing fails, no zone is left behind (see ImportZoneOptions.BatchSize).
+	ImportZone(ctx context.Context, serverID string, name string, r io.Reader, opts ImportZoneOptions) (*Zone, error)
 }
@@ -0,0 +1,126 @@
+package zones
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrConcurrentModification is returned by ModifyRecordSets when a zone was
+// modified concurrently in each attempt.
+var ErrConcurrentModification = errors.New("zone was modified concurrently")
+
+// DefaultModifyAttempts is the default number of attempts of ModifyRecordSets.
+const DefaultModifyAttempts = 5
+
+type modifyConfig struct {
+	maxAttempts int
+}
+
+// ModifyOption configures ModifyRecordSets.
+type ModifyOption func(c *modifyConfig)
+
+// WithMaxAttempts sets how often ModifyRecordSets tries to apply a
+// modification before giving up with ErrConcurrentModification. Values below
+// 1 are treated as 1.
+func WithMaxAttempts(n int) ModifyOption {
+	return func(c *modifyConfig) {
+		c.maxAttempts = max(n, 1)
+	}
+}
+
+// ModifyRecordSets performs an optimistic read-modify-write cycle on the
+// record sets of a zone. It reads the zone and passes it to mutate, which may
+// change, add or remove elements of zone.ResourceRecordSets. The resulting
+// changes are then applied in a single PATCH request, touching only the
+// record sets that were actually changed.
+//
+// Before writing, the zone is read again; if its serial, its edited serial or
+// any of the changed record sets differ from the first read, the zone was
+// modified concurrently, and the whole cycle (including mutate) is repeated
+// with fresh state. Since PowerDNS offers no conditional writes, a small
+// window remains between this check and the PATCH request.
+//
+// It returns the plan that was applied.
+func ModifyRecordSets(ctx context.Context, c Client, serverID, zoneID string, mutate func(zone *Zone) error, opts ...ModifyOption) (*Plan, error) {
+	cfg := modifyConfig{maxAttempts: DefaultModifyAttempts}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	for attempt := 0; attempt < cfg.maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		zone, err := c.GetZone(ctx, serverID, zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		original := copyRecordSets(zone.ResourceRecordSets)
+
+		if err := mutate(zone); err != nil {
+			return nil, err
+		}
+
+		plan := Diff(zone.ResourceRecordSets, original)
+		if plan.IsEmpty() {
+			return plan, nil
+		}
+
+		current, err := c.GetZone(ctx, serverID, zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		if current.Serial != zone.Serial || current.EditedSerial != zone.EditedSerial {
+			continue
+		}
+
+		if !Diff(touchedRecordSets(plan, original), touchedRecordSets(plan, current.ResourceRecordSets)).IsEmpty() {
+			continue
+		}
+
+		if err := c.PatchZone(ctx, serverID, zone.ID, plan.RecordSets()); err != nil {
+			return nil, err
+		}
+
+		return plan, nil
+	}
+
+	return nil, fmt.Errorf("%w: gave up after %d attempts", ErrConcurrentModification, cfg.maxAttempts)
+}
+
+// touchedRecordSets returns all record sets that are affected by a plan.
+func touchedRecordSets(plan *Plan, sets []ResourceRecordSet) []ResourceRecordSet {
+	touched := make(map[string]struct{}, len(plan.Changes))
+	for _, c := range plan.Changes {
+		touched[c.Name+" "+c.Type] = struct{}{}
+	}
+
+	out := make([]ResourceRecordSet, 0, len(plan.Changes))
+
+	for i := range sets {
+		if _, ok := touched[recordSetKey(&sets[i])]; ok {
+			out = append(out, sets[i])
+		}
+	}
+
+	return out
+}
+
+func copyRecordSets(sets []ResourceRecordSet) []ResourceRecordSet {
+	out := make([]ResourceRecordSet, len(sets))
+
+	for i, set := range sets {
+		set.Records = append([]Record(nil), set.Records...)
+		if set.Comments != nil {
+			set.Comments = append([]Comment{}, set.Comments...)
+		}
+
+		out[i] = set
+	}
+
+	return out
+}
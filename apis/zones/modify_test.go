@@ -0,0 +1,109 @@
+package zones_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addRecord(name, content string) func(z *zones.Zone) error {
+	return func(z *zones.Zone) error {
+		set := z.GetRecordSet(name, "A")
+		if set == nil {
+			z.ResourceRecordSets = append(z.ResourceRecordSets, zones.ResourceRecordSet{Name: name, Type: "A", TTL: 300})
+			set = &z.ResourceRecordSets[len(z.ResourceRecordSets)-1]
+		}
+
+		set.Records = append(set.Records, zones.Record{Content: content})
+		return nil
+	}
+}
+
+func TestModifyRecordSetsAppliesMutation(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+
+	plan, err := zones.ModifyRecordSets(context.Background(), c.Zones(), "localhost", "example.org.", addRecord("example.org.", "192.0.2.10"))
+	require.Nil(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, zones.ActionReplace, plan.Changes[0].Action)
+
+	z, _ := srv.Zone("example.org.")
+	assert.Len(t, z.GetRecordSet("example.org.", "A").Records, 2)
+	assert.NotNil(t, z.GetRecordSet("old.example.org.", "A"))
+}
+
+func TestModifyRecordSetsRetriesOnConcurrentModification(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+	calls := 0
+
+	mutate := func(z *zones.Zone) error {
+		calls++
+
+		// simulate another writer between our read and our write
+		if calls == 1 {
+			require.Nil(t, addRecord("example.org.", "192.0.2.20")(z))
+			require.Nil(t, c.Zones().AddRecordSetToZone(context.Background(), "localhost", "example.org.", *z.GetRecordSet("example.org.", "A")))
+		}
+
+		return addRecord("example.org.", "192.0.2.10")(z)
+	}
+
+	_, err := zones.ModifyRecordSets(context.Background(), c.Zones(), "localhost", "example.org.", mutate)
+	require.Nil(t, err)
+	assert.Equal(t, 2, calls)
+
+	z, _ := srv.Zone("example.org.")
+	assert.Equal(t, []zones.Record{{Content: "192.0.2.1"}, {Content: "192.0.2.20"}, {Content: "192.0.2.10"}}, z.GetRecordSet("example.org.", "A").Records)
+}
+
+func TestModifyRecordSetsGivesUpAfterMaxAttempts(t *testing.T) {
+	_, c := setupReconcilerTest(t)
+	calls := 0
+
+	mutate := func(z *zones.Zone) error {
+		calls++
+		require.Nil(t, c.Zones().AddRecordsToSet(context.Background(), "localhost", "example.org.", zones.ResourceRecordSet{
+			Name: "other.example.org.", Type: "TXT", TTL: 60, Records: []zones.Record{{Content: `"write"`}},
+		}))
+
+		return addRecord("example.org.", "192.0.2.10")(z)
+	}
+
+	_, err := zones.ModifyRecordSets(context.Background(), c.Zones(), "localhost", "example.org.", mutate, zones.WithMaxAttempts(3))
+
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, zones.ErrConcurrentModification))
+	assert.Equal(t, 3, calls)
+}
+
+func TestModifyRecordSetsDoesNotWriteWithoutChanges(t *testing.T) {
+	_, c := setupReconcilerTest(t)
+
+	plan, err := zones.ModifyRecordSets(context.Background(), c.Zones(), "localhost", "example.org.", func(*zones.Zone) error { return nil })
+
+	require.Nil(t, err)
+	assert.True(t, plan.IsEmpty())
+}
+
+func TestModifyRecordSetsReturnsMutationErrors(t *testing.T) {
+	_, c := setupReconcilerTest(t)
+	expected := errors.New("nope")
+
+	_, err := zones.ModifyRecordSets(context.Background(), c.Zones(), "localhost", "example.org.", func(*zones.Zone) error { return expected })
+
+	assert.Equal(t, expected, err)
+}
+
+func TestModifyRecordSetsMakesAtLeastOneAttempt(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+
+	_, err := zones.ModifyRecordSets(context.Background(), c.Zones(), "localhost", "example.org.", addRecord("example.org.", "192.0.2.10"), zones.WithMaxAttempts(0))
+	require.Nil(t, err)
+
+	z, _ := srv.Zone("example.org.")
+	assert.Len(t, z.GetRecordSet("example.org.", "A").Records, 2)
+}
@@ -0,0 +1,124 @@
+package zones
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+// ErrTooManyDeletes is returned by Reconciler.Reconcile when a plan would
+// delete more record sets than allowed.
+var ErrTooManyDeletes = errors.New("plan exceeds the maximum number of record set deletions")
+
+// ManagedFilter decides whether a record set of a zone is managed by a
+// Reconciler. Unmanaged record sets are neither changed nor deleted.
+type ManagedFilter func(zone *Zone, set *ResourceRecordSet) bool
+
+// DefaultManagedFilter manages all record sets except for the SOA record and
+// the NS records at the zone apex, which are usually maintained by PowerDNS
+// itself (or by whoever created the zone).
+func DefaultManagedFilter(zone *Zone, set *ResourceRecordSet) bool {
+	if !dnsname.Equal(set.Name, zone.Name) {
+		return true
+	}
+
+	switch strings.ToUpper(set.Type) {
+	case "SOA", "NS":
+		return false
+	}
+
+	return true
+}
+
+// Reconciler brings the record sets of zones into a desired state, by
+// computing a Plan (see Diff) and applying it as a single PATCH request.
+type Reconciler struct {
+	client     Client
+	serverID   string
+	dryRun     bool
+	managed    ManagedFilter
+	maxDeletes int
+}
+
+// ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(r *Reconciler)
+
+// WithDryRun causes the reconciler to compute plans without applying them.
+func WithDryRun() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.dryRun = true
+	}
+}
+
+// WithManagedFilter sets the filter that decides which record sets are
+// managed by the reconciler. The default is DefaultManagedFilter.
+func WithManagedFilter(f ManagedFilter) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.managed = f
+	}
+}
+
+// WithMaxDeletes limits the number of record sets that a single
+// reconciliation may delete; plans exceeding this limit are not applied, and
+// ErrTooManyDeletes is returned. A limit of 0 (the default) disables the check.
+func WithMaxDeletes(n int) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.maxDeletes = n
+	}
+}
+
+// NewReconciler creates a new reconciler for zones of the given server.
+func NewReconciler(c Client, serverID string, opts ...ReconcilerOption) *Reconciler {
+	r := Reconciler{
+		client:   c,
+		serverID: serverID,
+		managed:  DefaultManagedFilter,
+	}
+
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	return &r
+}
+
+// Reconcile brings the managed record sets of a zone into the desired state.
+// Desired record sets that are not managed are ignored. It returns the plan
+// that was applied (or, in dry-run mode, would have been applied).
+func (r *Reconciler) Reconcile(ctx context.Context, zoneID string, desired []ResourceRecordSet) (*Plan, error) {
+	zone, err := r.client.GetZone(ctx, r.serverID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := Diff(r.filter(zone, desired), r.filter(zone, zone.ResourceRecordSets))
+
+	if r.maxDeletes > 0 && plan.Count(ActionDelete) > r.maxDeletes {
+		return plan, fmt.Errorf("%w: %d deletions planned, at most %d allowed", ErrTooManyDeletes, plan.Count(ActionDelete), r.maxDeletes)
+	}
+
+	if r.dryRun || plan.IsEmpty() {
+		return plan, nil
+	}
+
+	if err := r.client.PatchZone(ctx, r.serverID, zone.ID, plan.RecordSets()); err != nil {
+		return plan, err
+	}
+
+	return plan, nil
+}
+
+func (r *Reconciler) filter(zone *Zone, sets []ResourceRecordSet) []ResourceRecordSet {
+	out := make([]ResourceRecordSet, 0, len(sets))
+
+	for i := range sets {
+		if r.managed(zone, &sets[i]) {
+			out = append(out, sets[i])
+		}
+	}
+
+	return out
+}
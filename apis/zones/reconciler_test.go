@@ -0,0 +1,128 @@
+package zones_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/pdnstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupReconcilerTest(t *testing.T) (*pdnstest.Server, pdns.Client) {
+	srv := pdnstest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	_, err = c.Zones().CreateZone(context.Background(), "localhost", zones.Zone{
+		Name:        "example.org.",
+		Nameservers: []string{"ns1.example.net.", "ns2.example.net."},
+		ResourceRecordSets: []zones.ResourceRecordSet{
+			{Name: "example.org.", Type: "A", TTL: 300, Records: []zones.Record{{Content: "192.0.2.1"}}},
+			{Name: "old.example.org.", Type: "A", TTL: 300, Records: []zones.Record{{Content: "192.0.2.2"}}},
+		},
+	})
+	require.Nil(t, err)
+
+	return srv, c
+}
+
+func desiredRecordSets() []zones.ResourceRecordSet {
+	return []zones.ResourceRecordSet{
+		{Name: "example.org.", Type: "A", TTL: 60, Records: []zones.Record{{Content: "192.0.2.1"}}},
+		{Name: "www.example.org.", Type: "CNAME", TTL: 300, Records: []zones.Record{{Content: "example.org."}}},
+	}
+}
+
+func TestPatchZoneAppliesMixedChangesAtomically(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+
+	err := c.Zones().PatchZone(context.Background(), "localhost", "example.org.", []zones.ResourceRecordSet{
+		{Name: "new.example.org.", Type: "A", TTL: 300, ChangeType: zones.ChangeTypeReplace, Records: []zones.Record{{Content: "192.0.2.3"}}},
+		{Name: "old.example.org.", Type: "A", ChangeType: zones.ChangeTypeDelete},
+	})
+	require.Nil(t, err)
+
+	z, _ := srv.Zone("example.org.")
+	assert.NotNil(t, z.GetRecordSet("new.example.org.", "A"))
+	assert.Nil(t, z.GetRecordSet("old.example.org.", "A"))
+}
+
+func TestPatchZoneRequiresChangeType(t *testing.T) {
+	_, c := setupReconcilerTest(t)
+
+	err := c.Zones().PatchZone(context.Background(), "localhost", "example.org.", []zones.ResourceRecordSet{
+		{Name: "new.example.org.", Type: "A", TTL: 300, Records: []zones.Record{{Content: "192.0.2.3"}}},
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestReconcilerAppliesPlan(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+
+	plan, err := zones.NewReconciler(c.Zones(), "localhost").Reconcile(context.Background(), "example.org.", desiredRecordSets())
+	require.Nil(t, err)
+
+	assert.Equal(t, 1, plan.Count(zones.ActionCreate))
+	assert.Equal(t, 1, plan.Count(zones.ActionReplace))
+	assert.Equal(t, 1, plan.Count(zones.ActionDelete))
+
+	z, _ := srv.Zone("example.org.")
+	assert.Equal(t, 60, z.GetRecordSet("example.org.", "A").TTL)
+	assert.NotNil(t, z.GetRecordSet("www.example.org.", "CNAME"))
+	assert.Nil(t, z.GetRecordSet("old.example.org.", "A"))
+
+	// SOA and apex NS are not managed by default, and must be retained
+	assert.NotNil(t, z.GetRecordSet("example.org.", "SOA"))
+	assert.NotNil(t, z.GetRecordSet("example.org.", "NS"))
+
+	plan, err = zones.NewReconciler(c.Zones(), "localhost").Reconcile(context.Background(), "example.org.", desiredRecordSets())
+	require.Nil(t, err)
+	assert.True(t, plan.IsEmpty())
+}
+
+func TestReconcilerDoesNotApplyPlanInDryRun(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+
+	plan, err := zones.NewReconciler(c.Zones(), "localhost", zones.WithDryRun()).Reconcile(context.Background(), "example.org.", desiredRecordSets())
+	require.Nil(t, err)
+	assert.False(t, plan.IsEmpty())
+
+	z, _ := srv.Zone("example.org.")
+	assert.NotNil(t, z.GetRecordSet("old.example.org.", "A"))
+}
+
+func TestReconcilerEnforcesMaxDeletes(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+
+	_, err := zones.NewReconciler(c.Zones(), "localhost", zones.WithMaxDeletes(1)).Reconcile(context.Background(), "example.org.", nil)
+
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, zones.ErrTooManyDeletes))
+
+	z, _ := srv.Zone("example.org.")
+	assert.NotNil(t, z.GetRecordSet("example.org.", "A"))
+}
+
+func TestReconcilerRespectsManagedFilter(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+
+	onlyWWW := func(_ *zones.Zone, set *zones.ResourceRecordSet) bool {
+		return set.Name == "www.example.org."
+	}
+
+	plan, err := zones.NewReconciler(c.Zones(), "localhost", zones.WithManagedFilter(onlyWWW)).Reconcile(context.Background(), "example.org.", desiredRecordSets())
+	require.Nil(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, zones.ActionCreate, plan.Changes[0].Action)
+
+	z, _ := srv.Zone("example.org.")
+	assert.NotNil(t, z.GetRecordSet("old.example.org.", "A"))
+	assert.Equal(t, 300, z.GetRecordSet("example.org.", "A").TTL)
+}
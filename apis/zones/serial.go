@@ -0,0 +1,56 @@
+package zones
+
+import "fmt"
+
+// Serial is an SOA serial number. Serial numbers wrap around, so they must be
+// compared and incremented using serial number arithmetic as defined in
+// RFC 1982, instead of plain integer arithmetic.
+type Serial uint32
+
+// maxSerialAddend is the largest value that may be added to a serial number.
+const maxSerialAddend = 1<<31 - 1
+
+// Compare compares two serial numbers according to RFC 1982. It returns -1 if
+// s is less than other, 1 if s is greater than other, and 0 if both are equal.
+//
+// For two serials that are exactly 2^31 apart, the comparison is undefined;
+// in this case, Compare also returns 0, while neither is Less than the other.
+func (s Serial) Compare(other Serial) int {
+	switch {
+	case s == other:
+		return 0
+	case s.Less(other):
+		return -1
+	case other.Less(s):
+		return 1
+	}
+
+	return 0
+}
+
+// Less returns true if s is less than other according to RFC 1982; i.e. if
+// other is "newer" than s, even if other has wrapped around.
+func (s Serial) Less(other Serial) bool {
+	return (s < other && other-s < 1<<31) || (s > other && s-other > 1<<31)
+}
+
+// Add adds n to a serial number, wrapping around if necessary. As defined in
+// RFC 1982, n must not be larger than 2^31-1.
+func (s Serial) Add(n uint32) (Serial, error) {
+	if n > maxSerialAddend {
+		return s, fmt.Errorf("cannot add %d to serial %d: addend must not exceed %d", n, s, maxSerialAddend)
+	}
+
+	return s + Serial(n), nil
+}
+
+// Increment returns the next serial number, wrapping around from 2^32-1 to 0.
+func (s Serial) Increment() Serial {
+	return s + 1
+}
+
+// SerialNumber returns the zone's serial as Serial. Note that the serial is
+// only populated when the zone was retrieved with GetZone or ListZones.
+func (z *Zone) SerialNumber() Serial {
+	return Serial(uint32(z.Serial))
+}
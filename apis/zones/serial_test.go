@@ -0,0 +1,125 @@
+package zones
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerialCompareUsesSerialNumberArithmetic(t *testing.T) {
+	assert.Equal(t, 0, Serial(1).Compare(1))
+	assert.Equal(t, -1, Serial(1).Compare(2))
+	assert.Equal(t, 1, Serial(2).Compare(1))
+
+	// wraparound: 2^32-1 < 0 < 2^31-1
+	assert.Equal(t, -1, Serial(4294967295).Compare(0))
+	assert.Equal(t, 1, Serial(0).Compare(4294967295))
+	assert.Equal(t, -1, Serial(0).Compare(2147483647))
+	assert.Equal(t, 1, Serial(0).Compare(2147483649))
+
+	// undefined comparison
+	assert.Equal(t, 0, Serial(0).Compare(2147483648))
+	assert.False(t, Serial(0).Less(2147483648))
+	assert.False(t, Serial(2147483648).Less(0))
+}
+
+func TestSerialAddWrapsAround(t *testing.T) {
+	s, err := Serial(4294967290).Add(10)
+	require.Nil(t, err)
+	assert.Equal(t, Serial(4), s)
+	assert.True(t, Serial(4294967290).Less(s))
+
+	_, err = Serial(1).Add(1 << 31)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, Serial(0), Serial(4294967295).Increment())
+}
+
+func TestZoneSerialNumber(t *testing.T) {
+	z := Zone{Serial: 2024031401}
+	assert.Equal(t, Serial(2024031401), z.SerialNumber())
+}
+
+// testNow is a Thursday, which is the first day of a week counted from the
+// Unix epoch; the inception used by SOA-EDIT is 2024-03-14 00:00 UTC.
+var testNow = time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+
+func TestZoneSOAEditApply(t *testing.T) {
+	cases := []struct {
+		policy   ZoneSOAEdit
+		serial   Serial
+		expected Serial
+	}{
+		{ZoneSOAEditUnset, 42, 42},
+		{ZoneSOAEditNone, 42, 42},
+		{ZoneSOAEditInceptionIncrement, 2024010101, 2024031401},
+		{ZoneSOAEditInceptionIncrement, 2024031400, 2024031402},
+		{ZoneSOAEditInceptionIncrement, 2024031699, 2024031701},
+		{ZoneSOAEditInceptionIncrement, 2024031700, 2024031700},
+		{ZoneSOAEditInceptionEpoch, 5, 1710374400},
+		{ZoneSOAEditInceptionEpoch, 2024031401, 2024031401},
+		{ZoneSOAEditIncrementWeeks, 10, 2838},
+		{ZoneSOAEditEpoch, 5, 1710417600},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.policy.Apply(c.serial, testNow), "policy %d, serial %d", c.policy, c.serial)
+	}
+}
+
+func TestZoneSOAEditAPIApply(t *testing.T) {
+	cases := []struct {
+		policy   ZoneSOAEditAPI
+		soaEdit  ZoneSOAEdit
+		serial   Serial
+		expected Serial
+	}{
+		{ZoneSOAEditAPIDefault, 0, 2024031305, 2024031401},
+		{ZoneSOAEditAPIDefault, 0, 2024031405, 2024031406},
+		{ZoneSOAEditAPIIncrease, 0, 4294967295, 0},
+		{ZoneSOAEditAPIEpoch, 0, 1, 1710417600},
+		{ZoneSOAEditAPISoaEdit, ZoneSOAEditInceptionEpoch, 1, 1710374400},
+		{ZoneSOAEditAPISoaEdit, ZoneSOAEditNone, 1, 1},
+		{ZoneSOAEditAPISoaEditIncrease, ZoneSOAEditInceptionEpoch, 1, 1710374400},
+		{ZoneSOAEditAPISoaEditIncrease, ZoneSOAEditInceptionEpoch, 2024031401, 2024031402},
+		{ZoneSOAEditAPINone, 0, 7, 7},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.policy.Apply(c.serial, c.soaEdit, testNow), "policy %d, serial %d", c.policy, c.serial)
+	}
+}
+
+func TestZoneNextSerial(t *testing.T) {
+	z := Zone{Serial: 2024031405, SOAEditAPI: ZoneSOAEditAPIDefault}
+	assert.Equal(t, Serial(2024031406), z.NextSerial(testNow))
+
+	z = Zone{Serial: 7}
+	assert.Equal(t, Serial(7), z.NextSerial(testNow))
+}
+
+func TestZoneSOAAndSetSOA(t *testing.T) {
+	z := Zone{
+		Name: "example.com.",
+		ResourceRecordSets: []ResourceRecordSet{
+			{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []Record{{Content: "ns1.example.com. hostmaster.example.com. 2024031401 10800 3600 604800 3600"}}},
+		},
+	}
+
+	soa, err := z.SOA()
+	require.Nil(t, err)
+	assert.Equal(t, Serial(2024031401), soa.Serial)
+
+	soa.Refresh = 7200
+	require.Nil(t, z.SetSOA(soa))
+	assert.Equal(t, "ns1.example.com. hostmaster.example.com. 2024031401 7200 3600 604800 3600", z.ResourceRecordSets[0].Records[0].Content)
+	assert.Equal(t, 3600, z.ResourceRecordSets[0].TTL)
+
+	soa.MName = "invalid"
+	assert.NotNil(t, z.SetSOA(soa))
+
+	_, err = (&Zone{Name: "example.com."}).SOA()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,98 @@
+package zones
+
+import "time"
+
+// dateSerial builds a serial number in the common YYYYMMDDnn format, for the
+// date of t in its location.
+func dateSerial(t time.Time, nn uint32) Serial {
+	return Serial(uint32(t.Year())*1000000 + uint32(t.Month())*10000 + uint32(t.Day())*100 + nn)
+}
+
+// inception returns the signature inception time that PowerDNS uses for
+// SOA-EDIT: the start of the current week, counted from the Unix epoch.
+func inception(now time.Time) time.Time {
+	const week = 7 * 24 * 60 * 60
+	return time.Unix(now.Unix()-now.Unix()%week, 0).In(now.Location())
+}
+
+// Apply emulates the SOA-EDIT policy, which PowerDNS applies to the serial of
+// the SOA record it serves for DNSSEC-signed zones. It returns the serial that
+// would be served at time now, for the given serial stored in the SOA record.
+//
+// PowerDNS uses the server's local time zone for date-based serials; pass now
+// in the same location to get matching results.
+func (v ZoneSOAEdit) Apply(serial Serial, now time.Time) Serial {
+	inc := inception(now)
+
+	switch v {
+	case ZoneSOAEditInceptionIncrement:
+		inceptionSerial := dateSerial(inc, 1)
+		dontIncrementAfter := dateSerial(inc.AddDate(0, 0, 2), 99)
+
+		if serial < inceptionSerial-1 {
+			return inceptionSerial
+		}
+
+		// <inceptionday>00 and <inceptionday>01 are reserved for new inceptions
+		if serial <= dontIncrementAfter {
+			return serial + 2
+		}
+
+	case ZoneSOAEditInceptionEpoch:
+		if epoch := Serial(uint32(inc.Unix())); serial < epoch {
+			return epoch
+		}
+
+	case ZoneSOAEditIncrementWeeks:
+		return serial + Serial(uint32(inc.Unix()/(7*24*60*60)))
+
+	case ZoneSOAEditEpoch:
+		return Serial(uint32(now.Unix()))
+	}
+
+	return serial
+}
+
+// Apply emulates the SOA-EDIT-API policy, which PowerDNS applies to the SOA
+// serial whenever a zone is changed using the API. It returns the serial that
+// the zone would have after a change at time now, for the current serial and
+// the zone's SOA-EDIT policy (which is used by SOA-EDIT and
+// SOA-EDIT-INCREASE).
+//
+// PowerDNS uses the server's local time zone for date-based serials; pass now
+// in the same location to get matching results.
+func (v ZoneSOAEditAPI) Apply(serial Serial, soaEdit ZoneSOAEdit, now time.Time) Serial {
+	switch v {
+	case ZoneSOAEditAPIDefault:
+		if today := dateSerial(now, 1); serial < today {
+			return today
+		}
+
+		return serial.Increment()
+
+	case ZoneSOAEditAPIIncrease:
+		return serial.Increment()
+
+	case ZoneSOAEditAPIEpoch:
+		return Serial(uint32(now.Unix()))
+
+	case ZoneSOAEditAPISoaEdit:
+		return soaEdit.Apply(serial, now)
+
+	case ZoneSOAEditAPISoaEditIncrease:
+		if next := soaEdit.Apply(serial, now); next > serial {
+			return next
+		}
+
+		return serial.Increment()
+	}
+
+	return serial
+}
+
+// NextSerial predicts the serial that the zone will have after the next change
+// using the API at time now, according to its SOA-EDIT-API policy. The serial
+// of a zone without SOA-EDIT-API policy is not changed by PowerDNS.
+func (z *Zone) NextSerial(now time.Time) Serial {
+	return z.SOAEditAPI.Apply(z.SerialNumber(), z.SOAEdit, now)
+}
@@ -1,5 +1,14 @@
 package zones
 
+import (
+	"encoding/json"
+	"time"
+)
+
+// ResourceRecordSet is a set of records with the same name and type. When
+// sending record sets to PowerDNS with ChangeTypeReplace, nil Records or
+// Comments leave the existing records or comments unchanged, while empty
+// slices remove them.
 type ResourceRecordSet struct {
 	Name       string              `json:"name"`
 	Type       string              `json:"type"`
@@ -15,8 +24,44 @@ type Record struct {
 	SetPTR   bool   `json:"set-ptr,omitempty"`
 }
 
+// Comment is a comment on a record set.
 type Comment struct {
+	Content string
+	Account string
+
+	// ModifiedAt is the time of the last change of the comment. When creating
+	// comments, PowerDNS uses the current time if ModifiedAt is zero.
+	ModifiedAt time.Time
+}
+
+type commentJSON struct {
 	Content    string `json:"content"`
 	Account    string `json:"account"`
-	ModifiedAt int    `json:"modified_at"`
+	ModifiedAt int64  `json:"modified_at,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface; ModifiedAt is encoded
+// as Unix timestamp, as expected by PowerDNS.
+func (c Comment) MarshalJSON() ([]byte, error) {
+	out := commentJSON{Content: c.Content, Account: c.Account}
+	if !c.ModifiedAt.IsZero() {
+		out.ModifiedAt = c.ModifiedAt.Unix()
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *Comment) UnmarshalJSON(b []byte) error {
+	in := commentJSON{}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	*c = Comment{Content: in.Content, Account: in.Account}
+	if in.ModifiedAt != 0 {
+		c.ModifiedAt = time.Unix(in.ModifiedAt, 0)
+	}
+
+	return nil
 }
@@ -0,0 +1,347 @@
+package zones
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+// ErrInvalidRecordData is returned (wrapped) when record data cannot be parsed
+// or fails validation.
+var ErrInvalidRecordData = errors.New("invalid record data")
+
+// RData is the typed data of a single resource record. Implementations convert
+// to and from the presentation format that PowerDNS uses in Record.Content.
+type RData interface {
+	// RecordType returns the record type, e.g. "MX".
+	RecordType() string
+
+	// Content returns the record data in presentation format, for use as
+	// Record.Content.
+	Content() string
+
+	// Validate checks the record data for errors that PowerDNS would reject.
+	Validate() error
+}
+
+var rdataParsers = map[string]func(content string) (RData, error){
+	"A":     func(c string) (RData, error) { return ParseA(c) },
+	"AAAA":  func(c string) (RData, error) { return ParseAAAA(c) },
+	"CNAME": func(c string) (RData, error) { return ParseCNAME(c) },
+	"NS":    func(c string) (RData, error) { return ParseNS(c) },
+	"PTR":   func(c string) (RData, error) { return ParsePTR(c) },
+	"MX":    func(c string) (RData, error) { return ParseMX(c) },
+	"SRV":   func(c string) (RData, error) { return ParseSRV(c) },
+	"TXT":   func(c string) (RData, error) { return ParseTXT(c) },
+	"CAA":   func(c string) (RData, error) { return ParseCAA(c) },
+	"SOA":   func(c string) (RData, error) { return ParseSOA(c) },
+	"DS":    func(c string) (RData, error) { return ParseDS(c) },
+	"TLSA":  func(c string) (RData, error) { return ParseTLSA(c) },
+	"SSHFP": func(c string) (RData, error) { return ParseSSHFP(c) },
+	"SVCB":  func(c string) (RData, error) { return ParseSVCB(c) },
+	"HTTPS": func(c string) (RData, error) { return ParseHTTPS(c) },
+	"NAPTR": func(c string) (RData, error) { return ParseNAPTR(c) },
+	"LOC":   func(c string) (RData, error) { return ParseLOC(c) },
+}
+
+// ParseRData parses the content of a record of the given type. An error is
+// returned for unsupported record types.
+func ParseRData(recordType, content string) (RData, error) {
+	parse, ok := rdataParsers[strings.ToUpper(recordType)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type: %s", recordType)
+	}
+
+	return parse(content)
+}
+
+// RData parses the content of all records of a record set.
+func (s ResourceRecordSet) RData() ([]RData, error) {
+	out := make([]RData, len(s.Records))
+
+	for i := range s.Records {
+		rd, err := ParseRData(s.Type, s.Records[i].Content)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = rd
+	}
+
+	return out, nil
+}
+
+// NewRecord validates record data and converts it into a Record.
+func NewRecord(data RData) (Record, error) {
+	if err := data.Validate(); err != nil {
+		return Record{}, err
+	}
+
+	return Record{Content: data.Content()}, nil
+}
+
+// NewRecordSet builds a record set from typed record data. All records need
+// to be of the same type; each record is validated.
+func NewRecordSet(name string, ttl int, data ...RData) (ResourceRecordSet, error) {
+	if len(data) == 0 {
+		return ResourceRecordSet{}, fmt.Errorf("%w: record set %s has no records", ErrInvalidRecordData, name)
+	}
+
+	if err := validateName(name); err != nil {
+		return ResourceRecordSet{}, fmt.Errorf("%w: record set name: %s", ErrInvalidRecordData, err)
+	}
+
+	if ttl < 0 || int64(ttl) > 1<<31-1 {
+		return ResourceRecordSet{}, fmt.Errorf("%w: TTL out of range: %d", ErrInvalidRecordData, ttl)
+	}
+
+	recordType := data[0].RecordType()
+	if (recordType == "CNAME" || recordType == "SOA") && len(data) > 1 {
+		return ResourceRecordSet{}, fmt.Errorf("%w: %s record set %s may only contain one record", ErrInvalidRecordData, recordType, name)
+	}
+
+	set := ResourceRecordSet{
+		Name:    name,
+		Type:    recordType,
+		TTL:     ttl,
+		Records: make([]Record, 0, len(data)),
+	}
+
+	seen := make(map[string]struct{}, len(data))
+
+	for _, d := range data {
+		if d.RecordType() != recordType {
+			return ResourceRecordSet{}, fmt.Errorf("%w: record set %s mixes %s and %s records", ErrInvalidRecordData, name, recordType, d.RecordType())
+		}
+
+		rec, err := NewRecord(d)
+		if err != nil {
+			return ResourceRecordSet{}, err
+		}
+
+		if _, ok := seen[rec.Content]; ok {
+			return ResourceRecordSet{}, fmt.Errorf("%w: duplicate record in %s record set %s: %s", ErrInvalidRecordData, recordType, name, rec.Content)
+		}
+
+		seen[rec.Content] = struct{}{}
+		set.Records = append(set.Records, rec)
+	}
+
+	return set, nil
+}
+
+func newTypedRecordSet[T RData](name string, ttl int, data []T) (ResourceRecordSet, error) {
+	rd := make([]RData, len(data))
+	for i := range data {
+		rd[i] = data[i]
+	}
+
+	return NewRecordSet(name, ttl, rd...)
+}
+
+// rdataField is a single whitespace-separated field of record data.
+type rdataField struct {
+	value  string
+	quoted bool
+}
+
+// splitRData splits record data into fields. Quoted fields may contain
+// whitespace, and are returned with their quotes removed and escapes
+// ("\X" and "\DDD") decoded. Unquoted fields are returned verbatim; quoted
+// parts inside them (as in `key="value"`) are kept including the quotes.
+func splitRData(content string) ([]rdataField, error) {
+	fields := make([]rdataField, 0, 8)
+	i := 0
+
+	for i < len(content) {
+		c := content[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '"':
+			value, n, err := readQuoted(content[i:])
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, rdataField{value: value, quoted: true})
+			i += n
+
+		default:
+			start := i
+
+			for i < len(content) && !isSpace(content[i]) {
+				switch content[i] {
+				case '\\':
+					i += 2
+				case '"':
+					_, n, err := readQuoted(content[i:])
+					if err != nil {
+						return nil, err
+					}
+
+					i += n
+				default:
+					i++
+				}
+			}
+
+			if i > len(content) {
+				i = len(content)
+			}
+
+			fields = append(fields, rdataField{value: content[start:i]})
+		}
+	}
+
+	return fields, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// unescapeString decodes "\X" and "\DDD" escapes in an unquoted string.
+func unescapeString(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	value, _, err := readQuoted(`"` + strings.ReplaceAll(s, `"`, `\"`) + `"`)
+	return value, err
+}
+
+// readQuoted reads a quoted string from the beginning of s, and returns its
+// decoded value and the number of bytes consumed (including both quotes).
+func readQuoted(s string) (string, int, error) {
+	out := strings.Builder{}
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return out.String(), i + 1, nil
+
+		case '\\':
+			if i+3 < len(s) && isDigit(s[i+1]) && isDigit(s[i+2]) && isDigit(s[i+3]) {
+				v, _ := strconv.Atoi(s[i+1 : i+4])
+				if v > 255 {
+					return "", 0, fmt.Errorf("%w: invalid escape sequence \\%s", ErrInvalidRecordData, s[i+1:i+4])
+				}
+
+				out.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+
+			if i+1 >= len(s) {
+				return "", 0, fmt.Errorf("%w: unterminated escape sequence", ErrInvalidRecordData)
+			}
+
+			out.WriteByte(s[i+1])
+			i++
+
+		default:
+			out.WriteByte(s[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated quoted string", ErrInvalidRecordData)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// quoteString formats s as a quoted character string, escaping quotes,
+// backslashes and non-printable bytes.
+func quoteString(s string) string {
+	out := strings.Builder{}
+	out.Grow(len(s) + 2)
+	out.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == '"' || c == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&out, "\\%03d", c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	out.WriteByte('"')
+	return out.String()
+}
+
+// expectFields splits record data, and checks the number of fields.
+func expectFields(recordType, content string, min, max int) ([]rdataField, error) {
+	fields, err := splitRData(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", recordType, err)
+	}
+
+	if len(fields) < min || (max >= 0 && len(fields) > max) {
+		return nil, fmt.Errorf("%w: %s: unexpected number of fields in %q", ErrInvalidRecordData, recordType, content)
+	}
+
+	return fields, nil
+}
+
+func parseUint(recordType, field, value string, bits int) (uint64, error) {
+	v, err := strconv.ParseUint(value, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: invalid %s %q", ErrInvalidRecordData, recordType, field, value)
+	}
+
+	return v, nil
+}
+
+// validateName checks that name is an absolute domain name in presentation
+// format. Internationalized names are rejected, since PowerDNS only accepts
+// their punycode form (see dnsname.Canonicalize).
+func validateName(name string) error {
+	if !dnsname.IsAbsolute(name) {
+		return fmt.Errorf("domain name %q is not absolute", name)
+	}
+
+	for i := 0; i < len(name); i++ {
+		if name[i] >= utf8.RuneSelf {
+			return fmt.Errorf("domain name %q contains non-ASCII characters; convert it using dnsname.Canonicalize", name)
+		}
+	}
+
+	return dnsname.Validate(name)
+}
+
+func validateNameField(recordType, field, name string) error {
+	if err := validateName(name); err != nil {
+		return fmt.Errorf("%w: %s: invalid %s: %s", ErrInvalidRecordData, recordType, field, err)
+	}
+
+	return nil
+}
+
+func validateHex(recordType, field, value string) error {
+	if value == "" || len(value)%2 != 0 {
+		return fmt.Errorf("%w: %s: invalid %s %q", ErrInvalidRecordData, recordType, field, value)
+	}
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if !isDigit(c) && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return fmt.Errorf("%w: %s: invalid %s %q", ErrInvalidRecordData, recordType, field, value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package zones
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+)
+
+// A is the data of an A record.
+type A struct {
+	Address netip.Addr
+}
+
+// ParseA parses the content of an A record.
+func ParseA(content string) (A, error) {
+	fields, err := expectFields("A", content, 1, 1)
+	if err != nil {
+		return A{}, err
+	}
+
+	addr, err := netip.ParseAddr(fields[0].value)
+	if err != nil || !addr.Is4() {
+		return A{}, fmt.Errorf("%w: A: invalid IPv4 address %q", ErrInvalidRecordData, fields[0].value)
+	}
+
+	return A{Address: addr}, nil
+}
+
+func (r A) RecordType() string { return "A" }
+func (r A) Content() string    { return r.Address.String() }
+
+func (r A) Validate() error {
+	if !r.Address.Is4() {
+		return fmt.Errorf("%w: A: invalid IPv4 address %q", ErrInvalidRecordData, r.Address)
+	}
+
+	return nil
+}
+
+// AAAA is the data of an AAAA record.
+type AAAA struct {
+	Address netip.Addr
+}
+
+// ParseAAAA parses the content of an AAAA record.
+func ParseAAAA(content string) (AAAA, error) {
+	fields, err := expectFields("AAAA", content, 1, 1)
+	if err != nil {
+		return AAAA{}, err
+	}
+
+	addr, err := netip.ParseAddr(fields[0].value)
+	if err != nil || !addr.Is6() {
+		return AAAA{}, fmt.Errorf("%w: AAAA: invalid IPv6 address %q", ErrInvalidRecordData, fields[0].value)
+	}
+
+	return AAAA{Address: addr}, nil
+}
+
+func (r AAAA) RecordType() string { return "AAAA" }
+func (r AAAA) Content() string    { return r.Address.String() }
+
+func (r AAAA) Validate() error {
+	if !r.Address.Is6() || r.Address.Zone() != "" {
+		return fmt.Errorf("%w: AAAA: invalid IPv6 address %q", ErrInvalidRecordData, r.Address)
+	}
+
+	return nil
+}
+
+// CNAME is the data of a CNAME record.
+type CNAME struct {
+	Target string
+}
+
+// ParseCNAME parses the content of a CNAME record.
+func ParseCNAME(content string) (CNAME, error) {
+	fields, err := expectFields("CNAME", content, 1, 1)
+	if err != nil {
+		return CNAME{}, err
+	}
+
+	return CNAME{Target: fields[0].value}, nil
+}
+
+func (r CNAME) RecordType() string { return "CNAME" }
+func (r CNAME) Content() string    { return r.Target }
+func (r CNAME) Validate() error    { return validateNameField("CNAME", "target", r.Target) }
+
+// NS is the data of an NS record.
+type NS struct {
+	Host string
+}
+
+// ParseNS parses the content of an NS record.
+func ParseNS(content string) (NS, error) {
+	fields, err := expectFields("NS", content, 1, 1)
+	if err != nil {
+		return NS{}, err
+	}
+
+	return NS{Host: fields[0].value}, nil
+}
+
+func (r NS) RecordType() string { return "NS" }
+func (r NS) Content() string    { return r.Host }
+func (r NS) Validate() error    { return validateNameField("NS", "host", r.Host) }
+
+// PTR is the data of a PTR record.
+type PTR struct {
+	Target string
+}
+
+// ParsePTR parses the content of a PTR record.
+func ParsePTR(content string) (PTR, error) {
+	fields, err := expectFields("PTR", content, 1, 1)
+	if err != nil {
+		return PTR{}, err
+	}
+
+	return PTR{Target: fields[0].value}, nil
+}
+
+func (r PTR) RecordType() string { return "PTR" }
+func (r PTR) Content() string    { return r.Target }
+func (r PTR) Validate() error    { return validateNameField("PTR", "target", r.Target) }
+
+// MX is the data of an MX record. Use "." as exchange for a null MX record
+// (RFC 7505).
+type MX struct {
+	Preference uint16
+	Exchange   string
+}
+
+// ParseMX parses the content of an MX record.
+func ParseMX(content string) (MX, error) {
+	fields, err := expectFields("MX", content, 2, 2)
+	if err != nil {
+		return MX{}, err
+	}
+
+	pref, err := parseUint("MX", "preference", fields[0].value, 16)
+	if err != nil {
+		return MX{}, err
+	}
+
+	return MX{Preference: uint16(pref), Exchange: fields[1].value}, nil
+}
+
+func (r MX) RecordType() string { return "MX" }
+func (r MX) Content() string    { return strconv.Itoa(int(r.Preference)) + " " + r.Exchange }
+func (r MX) Validate() error    { return validateNameField("MX", "exchange", r.Exchange) }
+
+// SRV is the data of an SRV record.
+type SRV struct {
+	Priority uint16
+	Weight   uint16
+	Port     uint16
+	Target   string
+}
+
+// ParseSRV parses the content of an SRV record.
+func ParseSRV(content string) (SRV, error) {
+	fields, err := expectFields("SRV", content, 4, 4)
+	if err != nil {
+		return SRV{}, err
+	}
+
+	var v [3]uint64
+	for i, name := range []string{"priority", "weight", "port"} {
+		if v[i], err = parseUint("SRV", name, fields[i].value, 16); err != nil {
+			return SRV{}, err
+		}
+	}
+
+	return SRV{Priority: uint16(v[0]), Weight: uint16(v[1]), Port: uint16(v[2]), Target: fields[3].value}, nil
+}
+
+func (r SRV) RecordType() string { return "SRV" }
+
+func (r SRV) Content() string {
+	return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Target)
+}
+
+func (r SRV) Validate() error { return validateNameField("SRV", "target", r.Target) }
+
+// NewARecordSet builds an A record set.
+func NewARecordSet(name string, ttl int, data ...A) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewAAAARecordSet builds an AAAA record set.
+func NewAAAARecordSet(name string, ttl int, data ...AAAA) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewCNAMERecordSet builds a CNAME record set.
+func NewCNAMERecordSet(name string, ttl int, data CNAME) (ResourceRecordSet, error) {
+	return NewRecordSet(name, ttl, data)
+}
+
+// NewNSRecordSet builds an NS record set.
+func NewNSRecordSet(name string, ttl int, data ...NS) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewPTRRecordSet builds a PTR record set.
+func NewPTRRecordSet(name string, ttl int, data ...PTR) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewMXRecordSet builds an MX record set.
+func NewMXRecordSet(name string, ttl int, data ...MX) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewSRVRecordSet builds an SRV record set.
+func NewSRVRecordSet(name string, ttl int, data ...SRV) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
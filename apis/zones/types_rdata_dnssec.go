@@ -0,0 +1,137 @@
+package zones
+
+import (
+	"fmt"
+	"strings"
+)
+
+// joinHex joins the remaining fields of record data, since hex-encoded data
+// may be split into several whitespace-separated chunks.
+func joinHex(fields []rdataField) string {
+	parts := make([]string, len(fields))
+	for i := range fields {
+		parts[i] = fields[i].value
+	}
+
+	return strings.ToLower(strings.Join(parts, ""))
+}
+
+// DS is the data of a DS record.
+type DS struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	Digest     string
+}
+
+// ParseDS parses the content of a DS record.
+func ParseDS(content string) (DS, error) {
+	fields, err := expectFields("DS", content, 4, -1)
+	if err != nil {
+		return DS{}, err
+	}
+
+	var v [3]uint64
+	for i, name := range []string{"key tag", "algorithm", "digest type"} {
+		bits := 8
+		if i == 0 {
+			bits = 16
+		}
+
+		if v[i], err = parseUint("DS", name, fields[i].value, bits); err != nil {
+			return DS{}, err
+		}
+	}
+
+	return DS{KeyTag: uint16(v[0]), Algorithm: uint8(v[1]), DigestType: uint8(v[2]), Digest: joinHex(fields[3:])}, nil
+}
+
+func (r DS) RecordType() string { return "DS" }
+
+func (r DS) Content() string {
+	return fmt.Sprintf("%d %d %d %s", r.KeyTag, r.Algorithm, r.DigestType, strings.ToLower(r.Digest))
+}
+
+func (r DS) Validate() error { return validateHex("DS", "digest", r.Digest) }
+
+// TLSA is the data of a TLSA record (RFC 6698).
+type TLSA struct {
+	Usage        uint8
+	Selector     uint8
+	MatchingType uint8
+	Certificate  string
+}
+
+// ParseTLSA parses the content of a TLSA record.
+func ParseTLSA(content string) (TLSA, error) {
+	fields, err := expectFields("TLSA", content, 4, -1)
+	if err != nil {
+		return TLSA{}, err
+	}
+
+	var v [3]uint64
+	for i, name := range []string{"usage", "selector", "matching type"} {
+		if v[i], err = parseUint("TLSA", name, fields[i].value, 8); err != nil {
+			return TLSA{}, err
+		}
+	}
+
+	return TLSA{Usage: uint8(v[0]), Selector: uint8(v[1]), MatchingType: uint8(v[2]), Certificate: joinHex(fields[3:])}, nil
+}
+
+func (r TLSA) RecordType() string { return "TLSA" }
+
+func (r TLSA) Content() string {
+	return fmt.Sprintf("%d %d %d %s", r.Usage, r.Selector, r.MatchingType, strings.ToLower(r.Certificate))
+}
+
+func (r TLSA) Validate() error {
+	return validateHex("TLSA", "certificate association data", r.Certificate)
+}
+
+// SSHFP is the data of an SSHFP record (RFC 4255).
+type SSHFP struct {
+	Algorithm   uint8
+	Type        uint8
+	Fingerprint string
+}
+
+// ParseSSHFP parses the content of an SSHFP record.
+func ParseSSHFP(content string) (SSHFP, error) {
+	fields, err := expectFields("SSHFP", content, 3, -1)
+	if err != nil {
+		return SSHFP{}, err
+	}
+
+	var v [2]uint64
+	for i, name := range []string{"algorithm", "fingerprint type"} {
+		if v[i], err = parseUint("SSHFP", name, fields[i].value, 8); err != nil {
+			return SSHFP{}, err
+		}
+	}
+
+	return SSHFP{Algorithm: uint8(v[0]), Type: uint8(v[1]), Fingerprint: joinHex(fields[2:])}, nil
+}
+
+func (r SSHFP) RecordType() string { return "SSHFP" }
+
+func (r SSHFP) Content() string {
+	return fmt.Sprintf("%d %d %s", r.Algorithm, r.Type, strings.ToLower(r.Fingerprint))
+}
+
+func (r SSHFP) Validate() error { return validateHex("SSHFP", "fingerprint", r.Fingerprint) }
+
+// NewDSRecordSet builds a DS record set.
+func NewDSRecordSet(name string, ttl int, data ...DS) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewTLSARecordSet builds a TLSA record set.
+func NewTLSARecordSet(name string, ttl int, data ...TLSA) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewSSHFPRecordSet builds an SSHFP record set.
+func NewSSHFPRecordSet(name string, ttl int, data ...SSHFP) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
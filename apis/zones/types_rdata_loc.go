@@ -0,0 +1,169 @@
+package zones
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LOC is the data of a LOC record (RFC 1876). Latitude and longitude are
+// given in degrees (negative for south and west), all other values in meters.
+type LOC struct {
+	Latitude       float64
+	Longitude      float64
+	Altitude       float64
+	Size           float64
+	HorizPrecision float64
+	VertPrecision  float64
+}
+
+// NewLOC returns a LOC record for the given coordinates, using the default
+// size and precisions of RFC 1876.
+func NewLOC(latitude, longitude, altitude float64) LOC {
+	return LOC{
+		Latitude:       latitude,
+		Longitude:      longitude,
+		Altitude:       altitude,
+		Size:           1,
+		HorizPrecision: 10000,
+		VertPrecision:  10,
+	}
+}
+
+// ParseLOC parses the content of a LOC record.
+func ParseLOC(content string) (LOC, error) {
+	fields, err := expectFields("LOC", content, 5, 16)
+	if err != nil {
+		return LOC{}, err
+	}
+
+	values := make([]string, len(fields))
+	for i := range fields {
+		values[i] = fields[i].value
+	}
+
+	out := NewLOC(0, 0, 0)
+
+	lat, rest, err := parseLOCCoordinate(values, "N", "S", 90)
+	if err != nil {
+		return LOC{}, err
+	}
+
+	lon, rest, err := parseLOCCoordinate(rest, "E", "W", 180)
+	if err != nil {
+		return LOC{}, err
+	}
+
+	if len(rest) == 0 || len(rest) > 4 {
+		return LOC{}, fmt.Errorf("%w: LOC: unexpected number of fields in %q", ErrInvalidRecordData, content)
+	}
+
+	out.Latitude = lat
+	out.Longitude = lon
+
+	targets := []*float64{&out.Altitude, &out.Size, &out.HorizPrecision, &out.VertPrecision}
+	for i, v := range rest {
+		f, err := strconv.ParseFloat(strings.TrimSuffix(v, "m"), 64)
+		if err != nil {
+			return LOC{}, fmt.Errorf("%w: LOC: invalid distance %q", ErrInvalidRecordData, v)
+		}
+
+		*targets[i] = f
+	}
+
+	return out, nil
+}
+
+// parseLOCCoordinate parses "d [m [s]] H" from the beginning of fields, and
+// returns the coordinate in degrees and the remaining fields.
+func parseLOCCoordinate(fields []string, pos, neg string, max float64) (float64, []string, error) {
+	var parts []float64
+
+	for i, f := range fields {
+		if f == pos || f == neg {
+			if len(parts) == 0 || len(parts) > 3 {
+				break
+			}
+
+			seconds := parts[0] * 3600
+			if len(parts) > 1 {
+				seconds += parts[1] * 60
+			}
+
+			if len(parts) > 2 {
+				seconds += parts[2]
+			}
+
+			deg := seconds / 3600
+
+			if deg > max {
+				return 0, nil, fmt.Errorf("%w: LOC: coordinate out of range", ErrInvalidRecordData)
+			}
+
+			if f == neg {
+				deg = -deg
+			}
+
+			return deg, fields[i+1:], nil
+		}
+
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil || v < 0 {
+			return 0, nil, fmt.Errorf("%w: LOC: invalid coordinate %q", ErrInvalidRecordData, f)
+		}
+
+		parts = append(parts, v)
+	}
+
+	return 0, nil, fmt.Errorf("%w: LOC: invalid coordinate, expected %s or %s", ErrInvalidRecordData, pos, neg)
+}
+
+func (r LOC) RecordType() string { return "LOC" }
+
+func (r LOC) Content() string {
+	return fmt.Sprintf("%s %s %.2fm %.2fm %.2fm %.2fm",
+		formatLOCCoordinate(r.Latitude, "N", "S"),
+		formatLOCCoordinate(r.Longitude, "E", "W"),
+		r.Altitude, r.Size, r.HorizPrecision, r.VertPrecision)
+}
+
+// formatLOCCoordinate formats a coordinate as "d m s.sss H", rounded to
+// milliseconds of arc.
+func formatLOCCoordinate(deg float64, pos, neg string) string {
+	hemisphere := pos
+	if deg < 0 {
+		hemisphere = neg
+	}
+
+	ms := int64(math.Round(math.Abs(deg) * 3600000))
+
+	return fmt.Sprintf("%d %d %d.%03d %s", ms/3600000, ms%3600000/60000, ms%60000/1000, ms%1000, hemisphere)
+}
+
+func (r LOC) Validate() error {
+	if math.IsNaN(r.Latitude) || math.Abs(r.Latitude) > 90 {
+		return fmt.Errorf("%w: LOC: latitude out of range: %f", ErrInvalidRecordData, r.Latitude)
+	}
+
+	if math.IsNaN(r.Longitude) || math.Abs(r.Longitude) > 180 {
+		return fmt.Errorf("%w: LOC: longitude out of range: %f", ErrInvalidRecordData, r.Longitude)
+	}
+
+	if math.IsNaN(r.Altitude) || r.Altitude < -100000 || r.Altitude > 42849672.95 {
+		return fmt.Errorf("%w: LOC: altitude out of range: %f", ErrInvalidRecordData, r.Altitude)
+	}
+
+	for _, v := range []float64{r.Size, r.HorizPrecision, r.VertPrecision} {
+		if math.IsNaN(v) || v < 0 || v > 90000000 {
+			return fmt.Errorf("%w: LOC: size or precision out of range: %f", ErrInvalidRecordData, v)
+		}
+	}
+
+	return nil
+}
+
+// NewLOCRecordSet builds a LOC record set.
+func NewLOCRecordSet(name string, ttl int, data ...LOC) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
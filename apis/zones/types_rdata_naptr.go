@@ -0,0 +1,67 @@
+package zones
+
+import "fmt"
+
+// NAPTR is the data of a NAPTR record (RFC 3403).
+type NAPTR struct {
+	Order       uint16
+	Preference  uint16
+	Flags       string
+	Services    string
+	Regexp      string
+	Replacement string
+}
+
+// ParseNAPTR parses the content of a NAPTR record.
+func ParseNAPTR(content string) (NAPTR, error) {
+	fields, err := expectFields("NAPTR", content, 6, 6)
+	if err != nil {
+		return NAPTR{}, err
+	}
+
+	order, err := parseUint("NAPTR", "order", fields[0].value, 16)
+	if err != nil {
+		return NAPTR{}, err
+	}
+
+	pref, err := parseUint("NAPTR", "preference", fields[1].value, 16)
+	if err != nil {
+		return NAPTR{}, err
+	}
+
+	return NAPTR{
+		Order:       uint16(order),
+		Preference:  uint16(pref),
+		Flags:       fields[2].value,
+		Services:    fields[3].value,
+		Regexp:      fields[4].value,
+		Replacement: fields[5].value,
+	}, nil
+}
+
+func (r NAPTR) RecordType() string { return "NAPTR" }
+
+func (r NAPTR) Content() string {
+	return fmt.Sprintf("%d %d %s %s %s %s",
+		r.Order, r.Preference, quoteString(r.Flags), quoteString(r.Services), quoteString(r.Regexp), r.Replacement)
+}
+
+func (r NAPTR) Validate() error {
+	for i := 0; i < len(r.Flags); i++ {
+		c := r.Flags[i]
+		if !isDigit(c) && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return fmt.Errorf("%w: NAPTR: invalid flags %q", ErrInvalidRecordData, r.Flags)
+		}
+	}
+
+	if r.Regexp != "" && r.Replacement != "." {
+		return fmt.Errorf("%w: NAPTR: regexp and replacement are mutually exclusive", ErrInvalidRecordData)
+	}
+
+	return validateNameField("NAPTR", "replacement", r.Replacement)
+}
+
+// NewNAPTRRecordSet builds a NAPTR record set.
+func NewNAPTRRecordSet(name string, ttl int, data ...NAPTR) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
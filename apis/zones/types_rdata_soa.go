@@ -0,0 +1,88 @@
+package zones
+
+import "fmt"
+
+// SOA is the data of an SOA record.
+type SOA struct {
+	MName   string
+	RName   string
+	Serial  Serial
+	Refresh uint32
+	Retry   uint32
+	Expire  uint32
+	Minimum uint32
+}
+
+// ParseSOA parses the content of an SOA record.
+func ParseSOA(content string) (SOA, error) {
+	fields, err := expectFields("SOA", content, 7, 7)
+	if err != nil {
+		return SOA{}, err
+	}
+
+	var v [5]uint64
+	for i, name := range []string{"serial", "refresh", "retry", "expire", "minimum"} {
+		if v[i], err = parseUint("SOA", name, fields[i+2].value, 32); err != nil {
+			return SOA{}, err
+		}
+	}
+
+	return SOA{
+		MName:   fields[0].value,
+		RName:   fields[1].value,
+		Serial:  Serial(v[0]),
+		Refresh: uint32(v[1]),
+		Retry:   uint32(v[2]),
+		Expire:  uint32(v[3]),
+		Minimum: uint32(v[4]),
+	}, nil
+}
+
+func (r SOA) RecordType() string { return "SOA" }
+
+func (r SOA) Content() string {
+	return fmt.Sprintf("%s %s %d %d %d %d %d", r.MName, r.RName, r.Serial, r.Refresh, r.Retry, r.Expire, r.Minimum)
+}
+
+func (r SOA) Validate() error {
+	if err := validateNameField("SOA", "mname", r.MName); err != nil {
+		return err
+	}
+
+	return validateNameField("SOA", "rname", r.RName)
+}
+
+// NewSOARecordSet builds an SOA record set.
+func NewSOARecordSet(name string, ttl int, data SOA) (ResourceRecordSet, error) {
+	return NewRecordSet(name, ttl, data)
+}
+
+// SOA returns the parsed SOA record at the zone apex. It fails if the zone
+// has no (or more than one) SOA record, e.g. because it was retrieved without
+// record sets.
+func (z *Zone) SOA() (SOA, error) {
+	set := z.GetRecordSet(z.Name, "SOA")
+	if set == nil || len(set.Records) != 1 {
+		return SOA{}, fmt.Errorf("zone %s has no SOA record", z.Name)
+	}
+
+	return ParseSOA(set.Records[0].Content)
+}
+
+// SetSOA replaces the SOA record at the zone apex, e.g. to change its timers.
+// The change only affects the local Zone value; use it in combination with
+// ModifyRecordSets to apply it. Leave the serial unchanged to let PowerDNS
+// update it according to the zone's SOA-EDIT-API policy.
+func (z *Zone) SetSOA(soa SOA) error {
+	if err := soa.Validate(); err != nil {
+		return err
+	}
+
+	set := z.GetRecordSet(z.Name, "SOA")
+	if set == nil {
+		return fmt.Errorf("zone %s has no SOA record", z.Name)
+	}
+
+	set.Records = []Record{{Content: soa.Content()}}
+	return nil
+}
@@ -0,0 +1,187 @@
+package zones
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// SVCParam is a single key/value parameter of an SVCB or HTTPS record, such
+// as "alpn=h2,h3". Value is empty for parameters without value.
+type SVCParam struct {
+	Key   string
+	Value string
+}
+
+// SVCB is the data of an SVCB record (RFC 9460). A priority of 0 denotes
+// alias mode, in which no parameters may be given.
+type SVCB struct {
+	Priority uint16
+	Target   string
+	Params   []SVCParam
+}
+
+// HTTPS is the data of an HTTPS record (RFC 9460). It has the same format as
+// an SVCB record.
+type HTTPS SVCB
+
+// ParseSVCB parses the content of an SVCB record.
+func ParseSVCB(content string) (SVCB, error) {
+	return parseSVCB("SVCB", content)
+}
+
+// ParseHTTPS parses the content of an HTTPS record.
+func ParseHTTPS(content string) (HTTPS, error) {
+	s, err := parseSVCB("HTTPS", content)
+	return HTTPS(s), err
+}
+
+func parseSVCB(recordType, content string) (SVCB, error) {
+	fields, err := expectFields(recordType, content, 2, -1)
+	if err != nil {
+		return SVCB{}, err
+	}
+
+	prio, err := parseUint(recordType, "priority", fields[0].value, 16)
+	if err != nil {
+		return SVCB{}, err
+	}
+
+	out := SVCB{Priority: uint16(prio), Target: fields[1].value}
+
+	for _, f := range fields[2:] {
+		key, value, hasValue := strings.Cut(f.value, "=")
+		if hasValue && strings.HasPrefix(value, `"`) {
+			unquoted, n, err := readQuoted(value)
+			if err != nil || n != len(value) {
+				return SVCB{}, fmt.Errorf("%w: %s: invalid value for parameter %q", ErrInvalidRecordData, recordType, key)
+			}
+
+			value = unquoted
+		}
+
+		out.Params = append(out.Params, SVCParam{Key: key, Value: value})
+	}
+
+	return out, nil
+}
+
+func (r SVCB) RecordType() string { return "SVCB" }
+func (r SVCB) Content() string    { return r.content() }
+func (r SVCB) Validate() error    { return r.validate("SVCB") }
+
+func (r HTTPS) RecordType() string { return "HTTPS" }
+func (r HTTPS) Content() string    { return SVCB(r).content() }
+func (r HTTPS) Validate() error    { return SVCB(r).validate("HTTPS") }
+
+func (r SVCB) content() string {
+	out := strings.Builder{}
+	out.WriteString(strconv.Itoa(int(r.Priority)))
+	out.WriteByte(' ')
+	out.WriteString(r.Target)
+
+	for _, p := range r.Params {
+		out.WriteByte(' ')
+		out.WriteString(p.Key)
+
+		if p.Value == "" {
+			continue
+		}
+
+		out.WriteByte('=')
+
+		if strings.ContainsAny(p.Value, " \t\";()") {
+			out.WriteString(quoteString(p.Value))
+		} else {
+			out.WriteString(p.Value)
+		}
+	}
+
+	return out.String()
+}
+
+func (r SVCB) validate(recordType string) error {
+	if err := validateNameField(recordType, "target", r.Target); err != nil {
+		return err
+	}
+
+	if r.Priority == 0 && len(r.Params) > 0 {
+		return fmt.Errorf("%w: %s: parameters are not allowed in alias mode", ErrInvalidRecordData, recordType)
+	}
+
+	seen := map[string]struct{}{}
+
+	for _, p := range r.Params {
+		if !isSVCParamKey(p.Key) {
+			return fmt.Errorf("%w: %s: invalid parameter key %q", ErrInvalidRecordData, recordType, p.Key)
+		}
+
+		if _, ok := seen[p.Key]; ok {
+			return fmt.Errorf("%w: %s: duplicate parameter %q", ErrInvalidRecordData, recordType, p.Key)
+		}
+
+		seen[p.Key] = struct{}{}
+
+		if err := validateSVCParamValue(p); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidRecordData, recordType, err)
+		}
+	}
+
+	return nil
+}
+
+func isSVCParamKey(key string) bool {
+	if key == "" || len(key) > 63 {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !isDigit(c) && (c < 'a' || c > 'z') && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateSVCParamValue checks the values of well-known parameters.
+func validateSVCParamValue(p SVCParam) error {
+	switch p.Key {
+	case "port":
+		if _, err := strconv.ParseUint(p.Value, 10, 16); err != nil {
+			return fmt.Errorf("invalid port %q", p.Value)
+		}
+
+	case "ipv4hint", "ipv6hint":
+		for _, s := range strings.Split(p.Value, ",") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil || (p.Key == "ipv4hint") != addr.Is4() {
+				return fmt.Errorf("invalid %s %q", p.Key, p.Value)
+			}
+		}
+
+	case "alpn", "mandatory":
+		if p.Value == "" {
+			return fmt.Errorf("parameter %q requires a value", p.Key)
+		}
+
+	case "no-default-alpn":
+		if p.Value != "" {
+			return fmt.Errorf("parameter %q must not have a value", p.Key)
+		}
+	}
+
+	return nil
+}
+
+// NewSVCBRecordSet builds an SVCB record set.
+func NewSVCBRecordSet(name string, ttl int, data ...SVCB) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewHTTPSRecordSet builds an HTTPS record set.
+func NewHTTPSRecordSet(name string, ttl int, data ...HTTPS) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
@@ -0,0 +1,172 @@
+package zones
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRDataRoundTrips(t *testing.T) {
+	data := []struct {
+		recordType string
+		content    string
+		expected   RData
+	}{
+		{"A", "192.0.2.1", A{Address: netip.MustParseAddr("192.0.2.1")}},
+		{"AAAA", "2001:db8::1", AAAA{Address: netip.MustParseAddr("2001:db8::1")}},
+		{"CNAME", "www.example.com.", CNAME{Target: "www.example.com."}},
+		{"NS", "ns1.example.com.", NS{Host: "ns1.example.com."}},
+		{"PTR", "host.example.com.", PTR{Target: "host.example.com."}},
+		{"MX", "10 mail.example.com.", MX{Preference: 10, Exchange: "mail.example.com."}},
+		{"MX", "0 .", MX{Preference: 0, Exchange: "."}},
+		{"SRV", "10 20 5060 sip.example.com.", SRV{Priority: 10, Weight: 20, Port: 5060, Target: "sip.example.com."}},
+		{"TXT", `"v=spf1 -all"`, TXT{Strings: []string{"v=spf1 -all"}}},
+		{"TXT", `"foo" "b\"ar\\"`, TXT{Strings: []string{"foo", `b"ar\`}}},
+		{"CAA", `0 issue "letsencrypt.org"`, CAA{Flags: 0, Tag: "issue", Value: "letsencrypt.org"}},
+		{"SOA", "ns1.example.com. hostmaster.example.com. 2024010101 10800 3600 604800 3600", SOA{
+			MName: "ns1.example.com.", RName: "hostmaster.example.com.",
+			Serial: 2024010101, Refresh: 10800, Retry: 3600, Expire: 604800, Minimum: 3600,
+		}},
+		{"DS", "12345 13 2 49fd46e6c4b45c55d4ac", DS{KeyTag: 12345, Algorithm: 13, DigestType: 2, Digest: "49fd46e6c4b45c55d4ac"}},
+		{"TLSA", "3 1 1 0123456789abcdef", TLSA{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "0123456789abcdef"}},
+		{"SSHFP", "4 2 abcdef01", SSHFP{Algorithm: 4, Type: 2, Fingerprint: "abcdef01"}},
+		{"SVCB", "0 svc.example.com.", SVCB{Priority: 0, Target: "svc.example.com."}},
+		{"HTTPS", "1 . alpn=h2,h3 port=8443 no-default-alpn", HTTPS{Priority: 1, Target: ".", Params: []SVCParam{
+			{Key: "alpn", Value: "h2,h3"}, {Key: "port", Value: "8443"}, {Key: "no-default-alpn"},
+		}}},
+		{"NAPTR", `100 10 "S" "SIP+D2U" "" _sip._udp.example.com.`, NAPTR{
+			Order: 100, Preference: 10, Flags: "S", Services: "SIP+D2U", Replacement: "_sip._udp.example.com.",
+		}},
+		{"LOC", "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m", LOC{
+			Latitude: (52*3600 + 22*60 + 23) / 3600.0, Longitude: (4*3600 + 53*60 + 32) / 3600.0,
+			Altitude: -2, Size: 0, HorizPrecision: 10000, VertPrecision: 10,
+		}},
+	}
+
+	for i := range data {
+		d := data[i]
+		t.Run(d.recordType+" "+d.content, func(t *testing.T) {
+			rd, err := ParseRData(d.recordType, d.content)
+
+			require.Nil(t, err)
+			assert.Equal(t, d.expected, rd)
+			assert.Nil(t, rd.Validate())
+			assert.Equal(t, d.recordType, rd.RecordType())
+			assert.Equal(t, d.content, rd.Content())
+		})
+	}
+}
+
+func TestRDataParsingFailsOnInvalidContent(t *testing.T) {
+	data := []struct {
+		recordType string
+		content    string
+	}{
+		{"A", "2001:db8::1"},
+		{"AAAA", "192.0.2.1"},
+		{"MX", "mail.example.com."},
+		{"MX", "65536 mail.example.com."},
+		{"SRV", "10 20 sip.example.com."},
+		{"TXT", `"unterminated`},
+		{"SOA", "ns1.example.com. hostmaster.example.com. 1 2 3 4"},
+		{"LOC", "52 22 23.000 X 4 53 32.000 E -2.00m"},
+	}
+
+	for i := range data {
+		d := data[i]
+		t.Run(d.recordType+" "+d.content, func(t *testing.T) {
+			_, err := ParseRData(d.recordType, d.content)
+
+			require.NotNil(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidRecordData))
+		})
+	}
+}
+
+func TestRDataParsingFailsOnUnsupportedType(t *testing.T) {
+	_, err := ParseRData("FOO", "bar")
+	assert.NotNil(t, err)
+}
+
+func TestRDataValidationCatchesErrors(t *testing.T) {
+	data := []RData{
+		CNAME{Target: "www.example.com"},
+		MX{Preference: 10, Exchange: "mail..example.com."},
+		TXT{},
+		CAA{Tag: "is sue", Value: "letsencrypt.org"},
+		DS{KeyTag: 1, Algorithm: 13, DigestType: 2, Digest: "xyz"},
+		SVCB{Priority: 0, Target: ".", Params: []SVCParam{{Key: "alpn", Value: "h2"}}},
+		HTTPS{Priority: 1, Target: ".", Params: []SVCParam{{Key: "port", Value: "http"}}},
+		LOC{Latitude: 91},
+	}
+
+	for i := range data {
+		t.Run(data[i].RecordType(), func(t *testing.T) {
+			err := data[i].Validate()
+
+			require.NotNil(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidRecordData))
+		})
+	}
+}
+
+func TestNewMXRecordSetBuildsRecordSet(t *testing.T) {
+	set, err := NewMXRecordSet("example.com.", 3600,
+		MX{Preference: 10, Exchange: "mx1.example.com."},
+		MX{Preference: 20, Exchange: "mx2.example.com."},
+	)
+
+	require.Nil(t, err)
+	assert.Equal(t, "example.com.", set.Name)
+	assert.Equal(t, "MX", set.Type)
+	assert.Equal(t, 3600, set.TTL)
+	assert.Equal(t, []Record{{Content: "10 mx1.example.com."}, {Content: "20 mx2.example.com."}}, set.Records)
+
+	rd, err := set.RData()
+	require.Nil(t, err)
+	assert.Equal(t, MX{Preference: 20, Exchange: "mx2.example.com."}, rd[1])
+}
+
+func TestNewRecordSetRejectsInvalidSets(t *testing.T) {
+	_, err := NewMXRecordSet("example.com.", 3600, MX{Preference: 10, Exchange: "mx1.example.com"})
+	assert.True(t, errors.Is(err, ErrInvalidRecordData))
+
+	_, err = NewMXRecordSet("example.com", 3600, MX{Preference: 10, Exchange: "mx1.example.com."})
+	assert.True(t, errors.Is(err, ErrInvalidRecordData))
+
+	_, err = NewRecordSet("example.com.", 3600, NS{Host: "ns1.example.com."}, CNAME{Target: "example.org."})
+	assert.True(t, errors.Is(err, ErrInvalidRecordData))
+
+	_, err = NewNSRecordSet("example.com.", 3600, NS{Host: "ns1.example.com."}, NS{Host: "ns1.example.com."})
+	assert.True(t, errors.Is(err, ErrInvalidRecordData))
+
+	_, err = NewARecordSet("example.com.", 3600)
+	assert.True(t, errors.Is(err, ErrInvalidRecordData))
+
+	_, err = NewARecordSet("bücher.example.", 3600, A{Address: netip.MustParseAddr("192.0.2.1")})
+	assert.True(t, errors.Is(err, ErrInvalidRecordData))
+
+	_, err = NewCNAMERecordSet("www.example.com.", 3600, CNAME{Target: "bücher.example."})
+	assert.True(t, errors.Is(err, ErrInvalidRecordData))
+}
+
+func TestLOCCoordinatesAreFormattedWithCarry(t *testing.T) {
+	loc := NewLOC(-33.99999999, 151.5, 10)
+
+	assert.Equal(t, "34 0 0.000 S 151 30 0.000 E 10.00m 1.00m 10000.00m 10.00m", loc.Content())
+}
+
+func TestLOCParsingAppliesDefaults(t *testing.T) {
+	loc, err := ParseLOC("42 21 54 N 71 6 18 W -24m")
+
+	require.Nil(t, err)
+	assert.InDelta(t, 42.365, loc.Latitude, 0.0001)
+	assert.InDelta(t, -71.105, loc.Longitude, 0.0001)
+	assert.Equal(t, -24.0, loc.Altitude)
+	assert.Equal(t, 1.0, loc.Size)
+	assert.Equal(t, 10000.0, loc.HorizPrecision)
+	assert.Equal(t, 10.0, loc.VertPrecision)
+}
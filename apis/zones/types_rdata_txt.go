@@ -0,0 +1,175 @@
+package zones
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxCharacterStringLength is the maximum length (in bytes) of a single
+// character string in a TXT record.
+const MaxCharacterStringLength = 255
+
+// TXT is the data of a TXT record, consisting of one or more character
+// strings of up to 255 bytes each. Use NewTXT to build a TXT record from a
+// value of arbitrary length.
+type TXT struct {
+	Strings []string
+}
+
+// NewTXT returns a TXT record for a value of arbitrary length, like a long
+// SPF or DKIM record, split into character strings as needed.
+func NewTXT(value string) TXT {
+	return TXT{Strings: SplitTXT(value)}
+}
+
+// Value returns the concatenation of all character strings of the record.
+func (r TXT) Value() string {
+	return strings.Join(r.Strings, "")
+}
+
+// SplitTXT splits a value into character strings of at most 255 bytes each.
+// Values are split at UTF-8 character boundaries, so that each chunk remains
+// valid UTF-8 if the input was.
+func SplitTXT(value string) []string {
+	if len(value) <= MaxCharacterStringLength {
+		return []string{value}
+	}
+
+	chunks := make([]string, 0, len(value)/MaxCharacterStringLength+1)
+
+	for len(value) > MaxCharacterStringLength {
+		cut := MaxCharacterStringLength
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+
+		if cut == 0 {
+			cut = MaxCharacterStringLength
+		}
+
+		chunks = append(chunks, value[:cut])
+		value = value[cut:]
+	}
+
+	return append(chunks, value)
+}
+
+// EncodeTXT encodes a value of arbitrary length as TXT record content, for
+// use as Record.Content. The value is split into quoted character strings,
+// and quotes, backslashes and non-printable bytes are escaped.
+func EncodeTXT(value string) string {
+	return NewTXT(value).Content()
+}
+
+// DecodeTXT decodes TXT record content (as returned by GetZone) into the
+// original value, by unescaping and concatenating all character strings.
+func DecodeTXT(content string) (string, error) {
+	txt, err := ParseTXT(content)
+	if err != nil {
+		return "", err
+	}
+
+	return txt.Value(), nil
+}
+
+// ParseTXT parses the content of a TXT record.
+func ParseTXT(content string) (TXT, error) {
+	fields, err := expectFields("TXT", content, 1, -1)
+	if err != nil {
+		return TXT{}, err
+	}
+
+	out := TXT{Strings: make([]string, len(fields))}
+	for i := range fields {
+		if fields[i].quoted {
+			out.Strings[i] = fields[i].value
+			continue
+		}
+
+		if out.Strings[i], err = unescapeString(fields[i].value); err != nil {
+			return TXT{}, fmt.Errorf("TXT: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
+func (r TXT) RecordType() string { return "TXT" }
+
+func (r TXT) Content() string {
+	quoted := make([]string, len(r.Strings))
+	for i := range r.Strings {
+		quoted[i] = quoteString(r.Strings[i])
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func (r TXT) Validate() error {
+	if len(r.Strings) == 0 {
+		return fmt.Errorf("%w: TXT: no character strings", ErrInvalidRecordData)
+	}
+
+	for i := range r.Strings {
+		if len(r.Strings[i]) > MaxCharacterStringLength {
+			return fmt.Errorf("%w: TXT: character string %d is longer than 255 bytes", ErrInvalidRecordData, i)
+		}
+	}
+
+	return nil
+}
+
+// CAA is the data of a CAA record (RFC 8659).
+type CAA struct {
+	Flags uint8
+	Tag   string
+	Value string
+}
+
+// ParseCAA parses the content of a CAA record.
+func ParseCAA(content string) (CAA, error) {
+	fields, err := expectFields("CAA", content, 3, 3)
+	if err != nil {
+		return CAA{}, err
+	}
+
+	flags, err := parseUint("CAA", "flags", fields[0].value, 8)
+	if err != nil {
+		return CAA{}, err
+	}
+
+	return CAA{Flags: uint8(flags), Tag: fields[1].value, Value: fields[2].value}, nil
+}
+
+func (r CAA) RecordType() string { return "CAA" }
+
+func (r CAA) Content() string {
+	return strconv.Itoa(int(r.Flags)) + " " + r.Tag + " " + quoteString(r.Value)
+}
+
+func (r CAA) Validate() error {
+	if r.Tag == "" || len(r.Tag) > 15 {
+		return fmt.Errorf("%w: CAA: invalid tag %q", ErrInvalidRecordData, r.Tag)
+	}
+
+	for i := 0; i < len(r.Tag); i++ {
+		c := r.Tag[i]
+		if !isDigit(c) && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return fmt.Errorf("%w: CAA: invalid tag %q", ErrInvalidRecordData, r.Tag)
+		}
+	}
+
+	return nil
+}
+
+// NewTXTRecordSet builds a TXT record set.
+func NewTXTRecordSet(name string, ttl int, data ...TXT) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
+
+// NewCAARecordSet builds a CAA record set.
+func NewCAARecordSet(name string, ttl int, data ...CAA) (ResourceRecordSet, error) {
+	return newTypedRecordSet(name, ttl, data)
+}
@@ -0,0 +1,106 @@
+package zones
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTXTKeepsShortValuesIntact(t *testing.T) {
+	assert.Equal(t, []string{"v=spf1 -all"}, SplitTXT("v=spf1 -all"))
+	assert.Equal(t, []string{""}, SplitTXT(""))
+}
+
+func TestSplitTXTSplitsLongValues(t *testing.T) {
+	value := strings.Repeat("a", 600)
+	chunks := SplitTXT(value)
+
+	require.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], 255)
+	assert.Len(t, chunks[1], 255)
+	assert.Len(t, chunks[2], 90)
+	assert.Equal(t, value, strings.Join(chunks, ""))
+}
+
+func TestSplitTXTSplitsAtCharacterBoundaries(t *testing.T) {
+	value := strings.Repeat("a", 254) + "ü" + "b"
+	chunks := SplitTXT(value)
+
+	require.Len(t, chunks, 2)
+	assert.Len(t, chunks[0], 254)
+	assert.True(t, utf8.ValidString(chunks[0]))
+	assert.True(t, utf8.ValidString(chunks[1]))
+}
+
+func TestEncodeTXTQuotesAndEscapes(t *testing.T) {
+	data := []struct {
+		value    string
+		expected string
+	}{
+		{"v=spf1 -all", `"v=spf1 -all"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`back\slash`, `"back\\slash"`},
+		{"tab\there", `"tab\009here"`},
+		{"ü", `"\195\188"`},
+	}
+
+	for i := range data {
+		t.Run(data[i].value, func(t *testing.T) {
+			assert.Equal(t, data[i].expected, EncodeTXT(data[i].value))
+		})
+	}
+}
+
+func TestEncodeTXTSplitsLongValues(t *testing.T) {
+	content := EncodeTXT(strings.Repeat("a", 300))
+
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, content)
+}
+
+func TestDecodeTXTReversesEncoding(t *testing.T) {
+	values := []string{
+		"v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12),
+		`quotes " and backslashes \ and tabs` + "\t",
+		"unicode: äöü",
+		"",
+	}
+
+	for _, v := range values {
+		decoded, err := DecodeTXT(EncodeTXT(v))
+
+		require.Nil(t, err)
+		assert.Equal(t, v, decoded)
+	}
+}
+
+func TestDecodeTXTHandlesEscapes(t *testing.T) {
+	data := []struct {
+		content  string
+		expected string
+	}{
+		{`"foo" "bar"`, "foobar"},
+		{`"\"quoted\""`, `"quoted"`},
+		{`"a\\b"`, `a\b`},
+		{`"\065\066C"`, "ABC"},
+		{`unquoted\032string`, "unquoted string"},
+	}
+
+	for i := range data {
+		t.Run(data[i].content, func(t *testing.T) {
+			decoded, err := DecodeTXT(data[i].content)
+
+			require.Nil(t, err)
+			assert.Equal(t, data[i].expected, decoded)
+		})
+	}
+}
+
+func TestDecodeTXTFailsOnInvalidContent(t *testing.T) {
+	for _, content := range []string{`"unterminated`, `"\256"`, ``} {
+		_, err := DecodeTXT(content)
+		assert.NotNil(t, err, content)
+	}
+}
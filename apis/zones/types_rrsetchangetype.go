@@ -8,6 +8,15 @@ const (
 	_                                    = iota
 	ChangeTypeDelete RecordSetChangeType = iota
 	ChangeTypeReplace
+
+	// ChangeTypeExtend adds the given records to a record set, creating it if
+	// necessary. Requires PowerDNS 5.0 or newer.
+	ChangeTypeExtend
+
+	// ChangeTypePrune removes only the given records from a record set, and
+	// deletes the record set if no records remain. Requires PowerDNS 5.0 or
+	// newer.
+	ChangeTypePrune
 )
 
 func (k RecordSetChangeType) MarshalJSON() ([]byte, error) {
@@ -16,6 +25,10 @@ func (k RecordSetChangeType) MarshalJSON() ([]byte, error) {
 		return []byte(`"DELETE"`), nil
 	case ChangeTypeReplace:
 		return []byte(`"REPLACE"`), nil
+	case ChangeTypeExtend:
+		return []byte(`"EXTEND"`), nil
+	case ChangeTypePrune:
+		return []byte(`"PRUNE"`), nil
 	default:
 		return nil, fmt.Errorf("unsupported change type: %d", k)
 	}
@@ -27,6 +40,10 @@ func (k *RecordSetChangeType) UnmarshalJSON(input []byte) error {
 		*k = ChangeTypeDelete
 	case `"REPLACE"`:
 		*k = ChangeTypeReplace
+	case `"EXTEND"`:
+		*k = ChangeTypeExtend
+	case `"PRUNE"`:
+		*k = ChangeTypePrune
 	default:
 		return fmt.Errorf("unsupported change type: %s", string(input))
 	}
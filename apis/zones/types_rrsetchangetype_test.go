@@ -14,6 +14,8 @@ func TestChangeTypeSerializesCorrectly(t *testing.T) {
 	}{
 		{ChangeTypeDelete, `"DELETE"`},
 		{ChangeTypeReplace, `"REPLACE"`},
+		{ChangeTypeExtend, `"EXTEND"`},
+		{ChangeTypePrune, `"PRUNE"`},
 	}
 
 	for i := range data {
@@ -40,6 +42,8 @@ func TestChangeTypeUnserializesCorrectly(t *testing.T) {
 	}{
 		{ChangeTypeDelete, `"DELETE"`},
 		{ChangeTypeReplace, `"REPLACE"`},
+		{ChangeTypeExtend, `"EXTEND"`},
+		{ChangeTypePrune, `"PRUNE"`},
 	}
 
 	for i := range data {
@@ -0,0 +1,39 @@
+package zones
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentModifiedAtIsDecodedFromUnixTimestamp(t *testing.T) {
+	c := Comment{}
+
+	err := json.Unmarshal([]byte(`{"content": "foo", "account": "alice", "modified_at": 1710417600}`), &c)
+
+	require.Nil(t, err)
+	assert.Equal(t, "foo", c.Content)
+	assert.Equal(t, "alice", c.Account)
+	assert.True(t, time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC).Equal(c.ModifiedAt))
+}
+
+func TestCommentModifiedAtIsEncodedAsUnixTimestamp(t *testing.T) {
+	j, err := json.Marshal(Comment{Content: "foo", Account: "alice", ModifiedAt: time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)})
+
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"content": "foo", "account": "alice", "modified_at": 1710417600}`, string(j))
+}
+
+func TestZeroCommentModifiedAtIsOmitted(t *testing.T) {
+	j, err := json.Marshal(Comment{Content: "foo"})
+
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"content": "foo", "account": ""}`, string(j))
+
+	c := Comment{}
+	require.Nil(t, json.Unmarshal([]byte(`{"content": "foo", "account": "", "modified_at": 0}`), &c))
+	assert.True(t, c.ModifiedAt.IsZero())
+}
@@ -1,6 +1,11 @@
 package zones
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mittwald/go-powerdns/dnsname"
+)
 
 // ZoneNameservers is a special list type to represent the nameservers of a zone.
 // When nil, this type will still serialize to an empty JSON list.
@@ -42,9 +47,12 @@ type Zone struct {
 	TSIGSlaveKeyIDs    []string            `json:"tsig_slave_key_ids,omitempty"`
 }
 
+// GetRecordSet returns the record set with the given name and type, or nil if
+// the zone has no such record set. Names are compared as domain names (see
+// dnsname.Equal), and types case-insensitively.
 func (z *Zone) GetRecordSet(name, recordType string) *ResourceRecordSet {
 	for i := range z.ResourceRecordSets {
-		if z.ResourceRecordSets[i].Name == name && z.ResourceRecordSets[i].Type == recordType {
+		if dnsname.Equal(z.ResourceRecordSets[i].Name, name) && strings.EqualFold(z.ResourceRecordSets[i].Type, recordType) {
 			return &z.ResourceRecordSets[i]
 		}
 	}
@@ -34,4 +34,21 @@ func TestNameserversAreSerializedToArray(t *testing.T) {
 
 	require.Nil(t, err)
 	require.Equal(t, `{"id":"1","name":"foo.example","type":"Zone","kind":"Master","nameservers":["ns.foo.example"]}`, string(j))
-}
\ No newline at end of file
+}
+func TestGetRecordSetComparesNamesAsDomainNames(t *testing.T) {
+	z := Zone{
+		ResourceRecordSets: []ResourceRecordSet{
+			{Name: "www.example.com.", Type: "A"},
+			{Name: "xn--bcher-kva.example.com.", Type: "A"},
+		},
+	}
+
+	for _, name := range []string{"www.example.com.", "WWW.example.com.", "www.example.com"} {
+		set := z.GetRecordSet(name, "A")
+		require.NotNil(t, set, name)
+		require.Equal(t, "www.example.com.", set.Name)
+	}
+
+	require.NotNil(t, z.GetRecordSet("bücher.example.com", "a"))
+	require.Nil(t, z.GetRecordSet("example.com.", "A"))
+}
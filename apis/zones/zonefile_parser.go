@@ -0,0 +1,476 @@
+package zones
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+// zoneFileEntry is a single logical line of a zone file, which may span
+// multiple physical lines when parentheses are used.
+type zoneFileEntry struct {
+	line     int
+	indented bool
+	tokens   []string
+}
+
+// rdataNameFields lists, for record types that contain domain names in their
+// data, the indexes of these fields. Relative names in these fields are made
+// absolute when parsing a zone file.
+var rdataNameFields = map[string][]int{
+	"CNAME": {0},
+	"DNAME": {0},
+	"NS":    {0},
+	"PTR":   {0},
+	"MX":    {1},
+	"SRV":   {3},
+	"SOA":   {0, 1},
+	"NAPTR": {5},
+	"SVCB":  {1},
+	"HTTPS": {1},
+	"AFSDB": {1},
+	"RP":    {0, 1},
+}
+
+// ParseZoneFile parses an RFC 1035 master file, like the output of ExportZone
+// or a BIND zone file, into a zone. Records with the same name and type are
+// grouped into record sets; all names are made absolute and canonical (see
+// dnsname.Canonicalize).
+//
+// The origin is used for relative names until a $ORIGIN directive is found,
+// and may be empty if the file starts with a $ORIGIN directive or only
+// contains absolute names. The zone name is the origin if given, or else the
+// owner of the SOA record, or else the first $ORIGIN of the file.
+//
+// $INCLUDE and $GENERATE directives are not supported.
+func ParseZoneFile(r io.Reader, origin string) (*Zone, error) {
+	entries, err := lexZoneFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if origin != "" {
+		if !dnsname.IsAbsolute(origin) {
+			return nil, fmt.Errorf("zone file origin %q is not absolute", origin)
+		}
+
+		if origin, err = dnsname.Canonicalize(origin); err != nil {
+			return nil, err
+		}
+	}
+
+	p := zoneFileParser{origin: origin, defaultTTL: -1, lastTTL: -1}
+
+	for _, e := range entries {
+		if err := p.parseEntry(e); err != nil {
+			return nil, fmt.Errorf("zone file line %d: %w", e.line, err)
+		}
+	}
+
+	zoneName := origin
+	if zoneName == "" {
+		zoneName = p.soaOwner
+	}
+
+	if zoneName == "" {
+		zoneName = p.firstOrigin
+	}
+
+	if zoneName == "" {
+		return nil, fmt.Errorf("zone file has neither an origin nor an SOA record")
+	}
+
+	return &Zone{
+		ID:                 zoneName,
+		Name:               zoneName,
+		Type:               ZoneTypeZone,
+		ResourceRecordSets: p.sets,
+	}, nil
+}
+
+type zoneFileParser struct {
+	origin      string
+	firstOrigin string
+	soaOwner    string
+	lastOwner   string
+	defaultTTL  int
+	lastTTL     int
+	sets        []ResourceRecordSet
+	index       map[string]int
+}
+
+func (p *zoneFileParser) parseEntry(e zoneFileEntry) error {
+	tokens := e.tokens
+
+	if strings.HasPrefix(tokens[0], "$") && !e.indented {
+		return p.parseDirective(tokens)
+	}
+
+	owner := p.lastOwner
+	if !e.indented {
+		name, err := p.absolute(tokens[0])
+		if err != nil {
+			return err
+		}
+
+		owner = name
+		tokens = tokens[1:]
+	}
+
+	if owner == "" {
+		return fmt.Errorf("record without owner name")
+	}
+
+	p.lastOwner = owner
+	ttl := -1
+
+	// TTL and class may occur in either order before the record type
+	for i := 0; i < 2 && len(tokens) > 0; i++ {
+		if v, ok := parseZoneFileTTL(tokens[0]); ok && ttl < 0 {
+			ttl = v
+			tokens = tokens[1:]
+			continue
+		}
+
+		switch strings.ToUpper(tokens[0]) {
+		case "IN":
+			tokens = tokens[1:]
+		case "CH", "CS", "HS":
+			return fmt.Errorf("unsupported class %s", tokens[0])
+		}
+	}
+
+	if len(tokens) == 0 {
+		return fmt.Errorf("record without type")
+	}
+
+	recordType := strings.ToUpper(tokens[0])
+	rdata := tokens[1:]
+
+	if len(rdata) == 0 {
+		return fmt.Errorf("%s record without data", recordType)
+	}
+
+	content, err := p.content(recordType, rdata)
+	if err != nil {
+		return err
+	}
+
+	if recordType == "SOA" {
+		if p.soaOwner == "" {
+			p.soaOwner = owner
+		}
+
+		// RFC 2308: without a $TTL, the SOA minimum is the default TTL
+		if soa, err := ParseSOA(content); err == nil && p.defaultTTL < 0 && ttl < 0 && p.lastTTL < 0 {
+			ttl = int(soa.Minimum)
+		}
+	}
+
+	switch {
+	case ttl >= 0:
+	case p.defaultTTL >= 0:
+		ttl = p.defaultTTL
+	case p.lastTTL >= 0:
+		ttl = p.lastTTL
+	default:
+		return fmt.Errorf("record without TTL, and no $TTL given")
+	}
+
+	p.lastTTL = ttl
+	p.addRecord(owner, recordType, ttl, content)
+
+	return nil
+}
+
+func (p *zoneFileParser) parseDirective(tokens []string) error {
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$ORIGIN requires exactly one argument")
+		}
+
+		origin, err := p.absolute(tokens[1])
+		if err != nil {
+			return err
+		}
+
+		p.origin = origin
+		if p.firstOrigin == "" {
+			p.firstOrigin = origin
+		}
+
+	case "$TTL":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$TTL requires exactly one argument")
+		}
+
+		ttl, ok := parseZoneFileTTL(tokens[1])
+		if !ok {
+			return fmt.Errorf("invalid $TTL %q", tokens[1])
+		}
+
+		p.defaultTTL = ttl
+
+	default:
+		return fmt.Errorf("unsupported directive %s", tokens[0])
+	}
+
+	return nil
+}
+
+// absolute converts a name from a zone file into a canonical absolute name.
+func (p *zoneFileParser) absolute(name string) (string, error) {
+	switch {
+	case name == "@" && p.origin == "":
+		return "", fmt.Errorf("@ used without origin")
+
+	case name != "@" && !dnsname.IsAbsolute(name) && p.origin == "":
+		return "", fmt.Errorf("relative name %q used without origin", name)
+	}
+
+	return dnsname.MakeAbsolute(name, p.origin)
+}
+
+// content builds the record content, as expected by PowerDNS, from the data
+// fields of a zone file record.
+func (p *zoneFileParser) content(recordType string, rdata []string) (string, error) {
+	fields := append([]string{}, rdata...)
+
+	if len(fields) > 0 && fields[0] == `\#` {
+		return strings.Join(fields, " "), nil
+	}
+
+	for _, idx := range rdataNameFields[recordType] {
+		if idx >= len(fields) {
+			continue
+		}
+
+		name, err := p.absolute(fields[idx])
+		if err != nil {
+			return "", err
+		}
+
+		fields[idx] = name
+	}
+
+	// SOA timers may use the BIND TTL format, which PowerDNS does not accept
+	if recordType == "SOA" {
+		for i := 3; i < len(fields); i++ {
+			if v, ok := parseZoneFileTTL(fields[i]); ok {
+				fields[i] = strconv.Itoa(v)
+			}
+		}
+	}
+
+	if recordType == "TXT" || recordType == "SPF" {
+		for i := range fields {
+			if !strings.HasPrefix(fields[i], `"`) {
+				value, err := unescapeString(fields[i])
+				if err != nil {
+					return "", err
+				}
+
+				fields[i] = quoteString(value)
+			}
+		}
+	}
+
+	return strings.Join(fields, " "), nil
+}
+
+func (p *zoneFileParser) addRecord(name, recordType string, ttl int, content string) {
+	if p.index == nil {
+		p.index = map[string]int{}
+	}
+
+	key := name + " " + recordType
+	idx, ok := p.index[key]
+
+	if !ok {
+		p.index[key] = len(p.sets)
+		p.sets = append(p.sets, ResourceRecordSet{
+			Name:     name,
+			Type:     recordType,
+			TTL:      ttl,
+			Records:  []Record{},
+			Comments: []Comment{},
+		})
+
+		idx = len(p.sets) - 1
+	}
+
+	set := &p.sets[idx]
+
+	// all records of a set share one TTL; RFC 2181 suggests using the lowest
+	if ttl < set.TTL {
+		set.TTL = ttl
+	}
+
+	for _, r := range set.Records {
+		if r.Content == content {
+			return
+		}
+	}
+
+	set.Records = append(set.Records, Record{Content: content})
+}
+
+// parseZoneFileTTL parses a TTL, either as plain number of seconds or in the
+// BIND format with units (e.g. "1h30m").
+func parseZoneFileTTL(s string) (int, bool) {
+	if s == "" || !isDigit(s[0]) {
+		return 0, false
+	}
+
+	if v, err := strconv.ParseUint(s, 10, 31); err == nil {
+		return int(v), true
+	}
+
+	total, current := 0, 0
+	hasDigits := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if isDigit(c) {
+			current = current*10 + int(c-'0')
+			hasDigits = true
+
+			if current > 1<<31-1 {
+				return 0, false
+			}
+
+			continue
+		}
+
+		if !hasDigits {
+			return 0, false
+		}
+
+		switch c {
+		case 's', 'S':
+			total += current
+		case 'm', 'M':
+			total += current * 60
+		case 'h', 'H':
+			total += current * 3600
+		case 'd', 'D':
+			total += current * 86400
+		case 'w', 'W':
+			total += current * 604800
+		default:
+			return 0, false
+		}
+
+		current, hasDigits = 0, false
+	}
+
+	total += current
+	if total > 1<<31-1 {
+		return 0, false
+	}
+
+	return total, true
+}
+
+// lexZoneFile splits a zone file into logical entries, handling comments,
+// quoted strings, escapes and parentheses.
+func lexZoneFile(r io.Reader) ([]zoneFileEntry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		entries []zoneFileEntry
+		current zoneFileEntry
+		token   strings.Builder
+		inToken bool
+		depth   int
+		lineNo  int
+	)
+
+	flush := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNo++
+
+		if depth == 0 {
+			current = zoneFileEntry{
+				line:     lineNo,
+				indented: len(line) > 0 && (line[0] == ' ' || line[0] == '\t'),
+			}
+		}
+
+	chars:
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+
+			switch {
+			case c == ';':
+				break chars
+
+			case isSpace(c):
+				flush()
+
+			case c == '(':
+				flush()
+				depth++
+
+			case c == ')':
+				flush()
+				depth--
+
+				if depth < 0 {
+					return nil, fmt.Errorf("zone file line %d: unbalanced parentheses", lineNo)
+				}
+
+			case c == '"':
+				_, n, err := readQuoted(line[i:])
+				if err != nil {
+					return nil, fmt.Errorf("zone file line %d: %w", lineNo, err)
+				}
+
+				token.WriteString(line[i : i+n])
+				inToken = true
+				i += n - 1
+
+			case c == '\\' && i+1 < len(line):
+				token.WriteString(line[i : i+2])
+				inToken = true
+				i++
+
+			default:
+				token.WriteByte(c)
+				inToken = true
+			}
+		}
+
+		flush()
+
+		if depth == 0 && len(current.tokens) > 0 {
+			entries = append(entries, current)
+			current = zoneFileEntry{}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if depth > 0 {
+		return nil, fmt.Errorf("zone file line %d: unbalanced parentheses", lineNo)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,143 @@
+package zones
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleBINDZoneFile = `
+$ORIGIN example.com.     ; designates the start of this zone file in the namespace
+$TTL 1h                  ; default expiration time of all resource records without their own TTL
+example.com.  IN  SOA   ns.example.com. username.example.com. (
+                          2020091025 ; serial
+                          2w         ; refresh
+                          1h         ; retry
+                          2w         ; expire
+                          1h )       ; minimum
+@             IN  NS    ns                    ; ns.example.com is a nameserver for example.com
+              IN  NS    ns.somewhere.example.
+@             IN  MX    10 mail.example.com.
+@             IN  MX    20 mail2
+@                 A     192.0.2.1
+                  AAAA  2001:db8:10::1
+ns        300 IN  A     192.0.2.2
+ns            IN  AAAA  2001:db8:10::2
+WWW           IN  CNAME example.com.
+wwwtest       IN  CNAME www
+mail          IN  A     192.0.2.3
+_sip._udp     IN  SRV   0 5 5060 sip
+txt           IN  TXT   "v=spf1 mx -all" "; not a comment"
+txt2          IN  TXT   unquoted
+$ORIGIN sub.example.com.
+host          IN  A     192.0.2.4
+`
+
+func TestParseZoneFileParsesBINDZoneFile(t *testing.T) {
+	z, err := ParseZoneFile(strings.NewReader(exampleBINDZoneFile), "")
+
+	require.Nil(t, err)
+	assert.Equal(t, "example.com.", z.Name)
+	assert.Equal(t, ZoneTypeZone, z.Type)
+
+	soa := z.GetRecordSet("example.com.", "SOA")
+	require.NotNil(t, soa)
+	assert.Equal(t, 3600, soa.TTL)
+	assert.Equal(t, "ns.example.com. username.example.com. 2020091025 1209600 3600 1209600 3600", soa.Records[0].Content)
+
+	ns := z.GetRecordSet("example.com.", "NS")
+	require.NotNil(t, ns)
+	assert.Equal(t, []Record{{Content: "ns.example.com."}, {Content: "ns.somewhere.example."}}, ns.Records)
+
+	mx := z.GetRecordSet("example.com.", "MX")
+	require.NotNil(t, mx)
+	assert.Equal(t, []Record{{Content: "10 mail.example.com."}, {Content: "20 mail2.example.com."}}, mx.Records)
+
+	require.NotNil(t, z.GetRecordSet("example.com.", "AAAA"))
+	assert.Equal(t, 300, z.GetRecordSet("ns.example.com.", "A").TTL)
+	assert.Equal(t, 3600, z.GetRecordSet("ns.example.com.", "AAAA").TTL)
+
+	require.NotNil(t, z.GetRecordSet("www.example.com.", "CNAME"))
+	assert.Equal(t, "www.example.com.", z.GetRecordSet("wwwtest.example.com.", "CNAME").Records[0].Content)
+	assert.Equal(t, "0 5 5060 sip.example.com.", z.GetRecordSet("_sip._udp.example.com.", "SRV").Records[0].Content)
+	assert.Equal(t, `"v=spf1 mx -all" "; not a comment"`, z.GetRecordSet("txt.example.com.", "TXT").Records[0].Content)
+	assert.Equal(t, `"unquoted"`, z.GetRecordSet("txt2.example.com.", "TXT").Records[0].Content)
+	require.NotNil(t, z.GetRecordSet("host.sub.example.com.", "A"))
+}
+
+func TestParseZoneFileParsesExportZoneOutput(t *testing.T) {
+	export := "example.de.\t3600\tIN\tNS\tns1.example.com.\n" +
+		"example.de.\t3600\tIN\tNS\tns2.example.com.\n" +
+		"example.de.\t3600\tIN\tSOA\ta.misconfigured.dns.server.invalid. hostmaster.example.de. 2024010101 10800 3600 604800 3600\n" +
+		"www.example.de.\t60\tIN\tA\t127.0.0.1\n"
+
+	z, err := ParseZoneFile(strings.NewReader(export), "")
+
+	require.Nil(t, err)
+	assert.Equal(t, "example.de.", z.Name)
+	assert.Len(t, z.ResourceRecordSets, 3)
+	assert.Len(t, z.GetRecordSet("example.de.", "NS").Records, 2)
+	assert.Equal(t, 60, z.GetRecordSet("www.example.de.", "A").TTL)
+}
+
+func TestParseZoneFileUsesGivenOrigin(t *testing.T) {
+	z, err := ParseZoneFile(strings.NewReader("$TTL 300\nwww IN A 192.0.2.1\n@ CNAME www\n"), "example.org.")
+
+	require.Nil(t, err)
+	assert.Equal(t, "example.org.", z.Name)
+	require.NotNil(t, z.GetRecordSet("www.example.org.", "A"))
+	assert.Equal(t, "www.example.org.", z.GetRecordSet("example.org.", "CNAME").Records[0].Content)
+}
+
+func TestParseZoneFileCanonicalizesNames(t *testing.T) {
+	z, err := ParseZoneFile(strings.NewReader("$TTL 300\nWWW IN CNAME Bücher\nBücher IN A 192.0.2.1\n"), "Example.ORG.")
+
+	require.Nil(t, err)
+	assert.Equal(t, "example.org.", z.Name)
+	require.NotNil(t, z.GetRecordSet("xn--bcher-kva.example.org.", "A"))
+	assert.Equal(t, "xn--bcher-kva.example.org.", z.GetRecordSet("www.example.org.", "CNAME").Records[0].Content)
+}
+
+func TestParseZoneFileUsesLowestTTLForRecordSet(t *testing.T) {
+	z, err := ParseZoneFile(strings.NewReader("www 300 IN A 192.0.2.1\nwww 60 IN A 192.0.2.2\n"), "example.org.")
+
+	require.Nil(t, err)
+	assert.Equal(t, 60, z.GetRecordSet("www.example.org.", "A").TTL)
+}
+
+func TestParseZoneFileFailsOnInvalidInput(t *testing.T) {
+	data := map[string]string{
+		"unbalanced parentheses": "@ 3600 IN SOA ns. host. ( 1 2 3 4 5\n",
+		"unterminated quotes":    `@ 3600 IN TXT "foo` + "\n",
+		"missing origin":         "www 3600 IN A 192.0.2.1\n",
+		"missing TTL":            "$ORIGIN example.org.\nwww IN A 192.0.2.1\n",
+		"unsupported include":    "$INCLUDE other.zone\n",
+		"unsupported class":      "$ORIGIN example.org.\nwww 3600 CH A 192.0.2.1\n",
+		"missing data":           "$ORIGIN example.org.\nwww 3600 IN A\n",
+	}
+
+	for name, input := range data {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseZoneFile(strings.NewReader(input), "")
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestParseZoneFileTTLSupportsUnits(t *testing.T) {
+	data := map[string]int{"3600": 3600, "1h": 3600, "1h30m": 5400, "2w": 1209600, "1d2h": 93600, "10S": 10}
+
+	for s, expected := range data {
+		v, ok := parseZoneFileTTL(s)
+
+		assert.True(t, ok, s)
+		assert.Equal(t, expected, v, s)
+	}
+
+	for _, s := range []string{"IN", "h", "1x", ""} {
+		_, ok := parseZoneFileTTL(s)
+		assert.False(t, ok, s)
+	}
+}
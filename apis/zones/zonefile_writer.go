@@ -0,0 +1,149 @@
+package zones
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+// ZoneFileOptions controls the output of WriteZoneFile.
+type ZoneFileOptions struct {
+	// RelativeNames causes owner names within the zone to be written relative
+	// to the zone origin (with "@" for the apex), preceded by a $ORIGIN
+	// directive. Names in record data are always written as absolute names.
+	RelativeNames bool
+
+	// FoldTTL causes the most common TTL to be written as $TTL directive, and
+	// omitted from all records that use it.
+	FoldTTL bool
+
+	// IncludeDisabled causes disabled records to be written as comments,
+	// instead of being omitted.
+	IncludeDisabled bool
+}
+
+// WriteZoneFile renders a zone as RFC 1035 master file. The output is
+// deterministic: the SOA and apex NS record sets come first, followed by all
+// other record sets in canonical name order (RFC 4034, section 6.1) and by
+// type; records within a set are sorted by content.
+func WriteZoneFile(w io.Writer, zone *Zone, opts ZoneFileOptions) error {
+	if zone.Name == "" {
+		return fmt.Errorf("zone has no name")
+	}
+
+	origin, err := dnsname.Canonicalize(zone.Name)
+	if err != nil {
+		return err
+	}
+
+	sets := make([]ResourceRecordSet, len(zone.ResourceRecordSets))
+	copy(sets, zone.ResourceRecordSets)
+
+	sort.SliceStable(sets, func(i, j int) bool {
+		return lessRecordSet(origin, &sets[i], &sets[j])
+	})
+
+	out := bufio.NewWriter(w)
+
+	if opts.RelativeNames {
+		fmt.Fprintf(out, "$ORIGIN %s\n", origin)
+	}
+
+	defaultTTL := -1
+	if opts.FoldTTL {
+		defaultTTL = mostCommonTTL(sets)
+		if defaultTTL >= 0 {
+			fmt.Fprintf(out, "$TTL %d\n", defaultTTL)
+		}
+	}
+
+	for i := range sets {
+		set := &sets[i]
+
+		owner := set.Name
+		if opts.RelativeNames {
+			if relative, err := dnsname.MakeRelative(set.Name, origin); err == nil {
+				owner = relative
+			}
+		}
+
+		ttl := fmt.Sprintf("%d\t", set.TTL)
+		if set.TTL == defaultTTL {
+			ttl = ""
+		}
+
+		records := make([]Record, len(set.Records))
+		copy(records, set.Records)
+		sort.SliceStable(records, func(a, b int) bool { return records[a].Content < records[b].Content })
+
+		for _, r := range records {
+			line := fmt.Sprintf("%s\t%sIN\t%s\t%s", owner, ttl, set.Type, r.Content)
+
+			if r.Disabled {
+				if !opts.IncludeDisabled {
+					continue
+				}
+
+				line = "; disabled: " + line
+			}
+
+			out.WriteString(line)
+			out.WriteByte('\n')
+		}
+	}
+
+	return out.Flush()
+}
+
+// lessRecordSet orders record sets for WriteZoneFile.
+func lessRecordSet(origin string, a, b *ResourceRecordSet) bool {
+	ra, rb := recordSetRank(origin, a), recordSetRank(origin, b)
+	if ra != rb {
+		return ra < rb
+	}
+
+	if c := dnsname.Compare(a.Name, b.Name); c != 0 {
+		return c < 0
+	}
+
+	return a.Type < b.Type
+}
+
+// recordSetRank places the SOA set first, and the apex NS set second.
+func recordSetRank(origin string, s *ResourceRecordSet) int {
+	if !dnsname.Equal(s.Name, origin) {
+		return 2
+	}
+
+	switch s.Type {
+	case "SOA":
+		return 0
+	case "NS":
+		return 1
+	}
+
+	return 2
+}
+
+// mostCommonTTL returns the TTL used by most records, preferring the lowest
+// TTL on ties, or -1 if there are no records.
+func mostCommonTTL(sets []ResourceRecordSet) int {
+	counts := map[int]int{}
+
+	for i := range sets {
+		counts[sets[i].TTL] += len(sets[i].Records)
+	}
+
+	best, bestCount := -1, 0
+
+	for ttl, count := range counts {
+		if count > bestCount || (count == bestCount && ttl < best) {
+			best, bestCount = ttl, count
+		}
+	}
+
+	return best
+}
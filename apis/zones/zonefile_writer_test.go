@@ -0,0 +1,69 @@
+package zones
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exampleZoneForWriting() *Zone {
+	return &Zone{
+		Name: "example.com.",
+		ResourceRecordSets: []ResourceRecordSet{
+			{Name: "www.example.com.", Type: "A", TTL: 3600, Records: []Record{{Content: "192.0.2.2"}, {Content: "192.0.2.1"}}},
+			{Name: "b.a.example.com.", Type: "TXT", TTL: 60, Records: []Record{{Content: `"hello"`}}},
+			{Name: "example.com.", Type: "NS", TTL: 3600, Records: []Record{{Content: "ns2.example.com."}, {Content: "ns1.example.com."}}},
+			{Name: "a.example.com.", Type: "A", TTL: 3600, Records: []Record{{Content: "192.0.2.3", Disabled: true}}},
+			{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []Record{{Content: "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"}}},
+			{Name: "example.com.", Type: "MX", TTL: 3600, Records: []Record{{Content: "10 mail.example.net."}}},
+		},
+	}
+}
+
+func TestWriteZoneFileWritesCanonicalOrder(t *testing.T) {
+	buf := bytes.Buffer{}
+	err := WriteZoneFile(&buf, exampleZoneForWriting(), ZoneFileOptions{})
+
+	require.Nil(t, err)
+	assert.Equal(t, "example.com.\t3600\tIN\tSOA\tns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600\n"+
+		"example.com.\t3600\tIN\tNS\tns1.example.com.\n"+
+		"example.com.\t3600\tIN\tNS\tns2.example.com.\n"+
+		"example.com.\t3600\tIN\tMX\t10 mail.example.net.\n"+
+		"b.a.example.com.\t60\tIN\tTXT\t\"hello\"\n"+
+		"www.example.com.\t3600\tIN\tA\t192.0.2.1\n"+
+		"www.example.com.\t3600\tIN\tA\t192.0.2.2\n", buf.String())
+}
+
+func TestWriteZoneFileSupportsRelativeNamesAndTTLFolding(t *testing.T) {
+	buf := bytes.Buffer{}
+	err := WriteZoneFile(&buf, exampleZoneForWriting(), ZoneFileOptions{RelativeNames: true, FoldTTL: true, IncludeDisabled: true})
+
+	require.Nil(t, err)
+	assert.Equal(t, "$ORIGIN example.com.\n"+
+		"$TTL 3600\n"+
+		"@\tIN\tSOA\tns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600\n"+
+		"@\tIN\tNS\tns1.example.com.\n"+
+		"@\tIN\tNS\tns2.example.com.\n"+
+		"@\tIN\tMX\t10 mail.example.net.\n"+
+		"; disabled: a\tIN\tA\t192.0.2.3\n"+
+		"b.a\t60\tIN\tTXT\t\"hello\"\n"+
+		"www\tIN\tA\t192.0.2.1\n"+
+		"www\tIN\tA\t192.0.2.2\n", buf.String())
+}
+
+func TestWriteZoneFileOutputCanBeParsed(t *testing.T) {
+	for _, opts := range []ZoneFileOptions{{}, {RelativeNames: true, FoldTTL: true}} {
+		buf := bytes.Buffer{}
+		require.Nil(t, WriteZoneFile(&buf, exampleZoneForWriting(), opts))
+
+		z, err := ParseZoneFile(&buf, "")
+
+		require.Nil(t, err)
+		assert.Equal(t, "example.com.", z.Name)
+		assert.Len(t, z.ResourceRecordSets, 5)
+		assert.Equal(t, 60, z.GetRecordSet("b.a.example.com.", "TXT").TTL)
+		assert.Len(t, z.GetRecordSet("www.example.com.", "A").Records, 2)
+	}
+}
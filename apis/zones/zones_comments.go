@@ -0,0 +1,37 @@
+package zones
+
+import "context"
+
+func (c *client) AddCommentsToRecordSet(ctx context.Context, serverID, zoneID, name, recordType string, comments ...Comment) error {
+	zone, err := c.GetZone(ctx, serverID, zoneID, WithResourceRecordSetFilter(name, recordType))
+	if err != nil {
+		return err
+	}
+
+	existing := []Comment{}
+	if set := zone.GetRecordSet(name, recordType); set != nil {
+		existing = append(existing, set.Comments...)
+	}
+
+	return c.ReplaceRecordSetComments(ctx, serverID, zoneID, name, recordType, append(existing, comments...))
+}
+
+func (c *client) ReplaceRecordSetComments(ctx context.Context, serverID, zoneID, name, recordType string, comments []Comment) error {
+	if comments == nil {
+		comments = []Comment{}
+	}
+
+	// without records, PowerDNS only replaces the comments of the set
+	set := ResourceRecordSet{
+		Name:       name,
+		Type:       recordType,
+		ChangeType: ChangeTypeReplace,
+		Comments:   comments,
+	}
+
+	return c.PatchZone(ctx, serverID, zoneID, []ResourceRecordSet{set})
+}
+
+func (c *client) ClearRecordSetComments(ctx context.Context, serverID, zoneID, name, recordType string) error {
+	return c.ReplaceRecordSetComments(ctx, serverID, zoneID, name, recordType, []Comment{})
+}
@@ -0,0 +1,76 @@
+package zones_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCommentsToRecordSetKeepsRecordsAndComments(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+	ctx := context.Background()
+
+	err := c.Zones().AddCommentsToRecordSet(ctx, "localhost", "example.org.", "example.org.", "A", zones.Comment{Content: "created", Account: "alice"})
+	require.Nil(t, err)
+
+	err = c.Zones().AddCommentsToRecordSet(ctx, "localhost", "example.org.", "example.org.", "A", zones.Comment{Content: "changed", Account: "bob"})
+	require.Nil(t, err)
+
+	z, _ := srv.Zone("example.org.")
+	set := z.GetRecordSet("example.org.", "A")
+
+	assert.Equal(t, []zones.Record{{Content: "192.0.2.1"}}, set.Records)
+	require.Len(t, set.Comments, 2)
+	assert.Equal(t, "created", set.Comments[0].Content)
+	assert.Equal(t, "bob", set.Comments[1].Account)
+	assert.WithinDuration(t, time.Now(), set.Comments[1].ModifiedAt, time.Minute)
+}
+
+func TestReplaceAndClearRecordSetComments(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+	ctx := context.Background()
+
+	modified := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	require.Nil(t, c.Zones().AddCommentsToRecordSet(ctx, "localhost", "example.org.", "example.org.", "A", zones.Comment{Content: "old"}))
+	require.Nil(t, c.Zones().ReplaceRecordSetComments(ctx, "localhost", "example.org.", "example.org.", "A", []zones.Comment{{Content: "new", Account: "alice", ModifiedAt: modified}}))
+
+	z, _ := srv.Zone("example.org.")
+	set := z.GetRecordSet("example.org.", "A")
+
+	require.Len(t, set.Comments, 1)
+	assert.Equal(t, "new", set.Comments[0].Content)
+	assert.True(t, modified.Equal(set.Comments[0].ModifiedAt))
+	assert.Len(t, set.Records, 1)
+
+	require.Nil(t, c.Zones().ClearRecordSetComments(ctx, "localhost", "example.org.", "example.org.", "A"))
+
+	z, _ = srv.Zone("example.org.")
+	set = z.GetRecordSet("example.org.", "A")
+
+	assert.Empty(t, set.Comments)
+	assert.Len(t, set.Records, 1)
+}
+
+func TestAddRecordSetsToZoneKeepsCommentsWhenNil(t *testing.T) {
+	srv, c := setupReconcilerTest(t)
+	ctx := context.Background()
+
+	require.Nil(t, c.Zones().AddCommentsToRecordSet(ctx, "localhost", "example.org.", "example.org.", "A", zones.Comment{Content: "keep me"}))
+
+	err := c.Zones().AddRecordSetsToZone(ctx, "localhost", "example.org.", []zones.ResourceRecordSet{
+		{Name: "example.org.", Type: "A", TTL: 300, Records: []zones.Record{{Content: "192.0.2.10"}}},
+	})
+	require.Nil(t, err)
+
+	z, _ := srv.Zone("example.org.")
+	set := z.GetRecordSet("example.org.", "A")
+
+	assert.Equal(t, []zones.Record{{Content: "192.0.2.10"}}, set.Records)
+	require.Len(t, set.Comments, 1)
+	assert.Equal(t, "keep me", set.Comments[0].Content)
+}
@@ -0,0 +1,61 @@
+package zones
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const exampleExport = "example.com.\t3600\tIN\tSOA\tns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600\n" +
+	"www.example.com.\t60\tIN\tA\t192.0.2.1\n"
+
+func TestExportZoneToWritesZone(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/example.com./export").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "text/plain").
+		BodyString(exampleExport)
+
+	out := bytes.Buffer{}
+	err := newTestClient().ExportZoneTo(context.Background(), "localhost", "example.com.", &out)
+
+	require.Nil(t, err)
+	assert.Equal(t, exampleExport, out.String())
+}
+
+func TestExportZoneToReturnsNotFoundForUnknownZones(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/unknown./export").
+		Reply(http.StatusUnprocessableEntity).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"error": "Could not find domain 'unknown.'"}`)
+
+	err := newTestClient().ExportZoneTo(context.Background(), "localhost", "unknown.", &bytes.Buffer{})
+
+	assert.True(t, pdnshttp.IsNotFound(err))
+}
+
+func TestExportZoneToKeepsOtherUnprocessableErrors(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/example.com./export").
+		Reply(http.StatusUnprocessableEntity).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"error": "Zone is corrupt"}`)
+
+	err := newTestClient().ExportZoneTo(context.Background(), "localhost", "example.com.", &bytes.Buffer{})
+
+	assert.False(t, pdnshttp.IsNotFound(err))
+	assert.True(t, pdnshttp.IsUnprocessable(err))
+}
@@ -2,10 +2,14 @@ package zones
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/mittwald/go-powerdns/pdnshttp"
+	"iter"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type GetZoneOption interface {
@@ -33,6 +37,34 @@ func WithResourceRecordSetFilter(name, recordType string) GetZoneOption {
 	})
 }
 
+// recordSetOption is implemented by GetZoneOptions that modify the record sets
+// of the response after it was decoded.
+type recordSetOption interface {
+	applyToRecordSet(set *ResourceRecordSet)
+}
+
+type withoutComments struct{}
+
+func (withoutComments) ApplyToGetZoneRequest(*http.Request) error { return nil }
+
+func (withoutComments) applyToRecordSet(set *ResourceRecordSet) { set.Comments = nil }
+
+// WithoutComments removes the comments from all returned record sets. PowerDNS
+// always includes comments in its responses; but without comments, record sets
+// can be modified and written back without replacing comments that were
+// changed concurrently (see ResourceRecordSet).
+func WithoutComments() GetZoneOption {
+	return withoutComments{}
+}
+
+func applyRecordSetOptions(set *ResourceRecordSet, opts []GetZoneOption) {
+	for _, opt := range opts {
+		if o, ok := opt.(recordSetOption); ok {
+			o.applyToRecordSet(set)
+		}
+	}
+}
+
 func (c *client) GetZone(ctx context.Context, serverID, zoneID string, opts ...GetZoneOption) (*Zone, error) {
 	zone := Zone{}
 	path := fmt.Sprintf("/servers/%s/zones/%s", url.PathEscape(serverID), url.PathEscape(zoneID))
@@ -49,14 +81,71 @@ func (c *client) GetZone(ctx context.Context, serverID, zoneID string, opts ...G
 	}
 
 	if err := c.httpClient.Do(ctx, req, &zone); err != nil {
-		if e, ok := err.(pdnshttp.ErrUnexpectedStatus); ok {
-			if e.StatusCode == http.StatusUnprocessableEntity {
-				return nil, pdnshttp.ErrNotFound{}
+		return nil, mapZoneNotFound(err)
+	}
+
+	for i := range zone.ResourceRecordSets {
+		applyRecordSetOptions(&zone.ResourceRecordSets[i], opts)
+	}
+
+	return &zone, nil
+}
+
+func (c *client) IterateRecordSets(ctx context.Context, serverID, zoneID string, opts ...GetZoneOption) iter.Seq2[ResourceRecordSet, error] {
+	return func(yield func(ResourceRecordSet, error) bool) {
+		path := fmt.Sprintf("/servers/%s/zones/%s", url.PathEscape(serverID), url.PathEscape(zoneID))
+
+		req, err := c.httpClient.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			yield(ResourceRecordSet{}, err)
+			return
+		}
+
+		for _, opt := range opts {
+			if err := opt.ApplyToGetZoneRequest(req); err != nil {
+				yield(ResourceRecordSet{}, err)
+				return
 			}
 		}
 
-		return nil, err
+		err = c.httpClient.DoStream(ctx, req, func(res *http.Response) error {
+			return decodeJSONObjectField(json.NewDecoder(res.Body), "rrsets", func(dec *json.Decoder) error {
+				return decodeJSONArray(dec, func(dec *json.Decoder) error {
+					set := ResourceRecordSet{}
+					if err := dec.Decode(&set); err != nil {
+						return err
+					}
+
+					applyRecordSetOptions(&set, opts)
+
+					if !yield(set, nil) {
+						return errStopDecoding
+					}
+
+					return nil
+				})
+			})
+		})
+
+		if err != nil && !errors.Is(err, errStopDecoding) {
+			yield(ResourceRecordSet{}, mapZoneNotFound(err))
+		}
+	}
+}
+
+// zoneNotFoundMessage is contained in the message with which (older versions
+// of) PowerDNS respond to requests for nonexistent zones, with status 422; for
+// example, "Could not find domain 'example.com.'".
+const zoneNotFoundMessage = "Could not find domain"
+
+// mapZoneNotFound converts the error that PowerDNS returns for nonexistent
+// zones (see zoneNotFoundMessage) into an ErrNotFound. Other errors are
+// returned unchanged.
+func mapZoneNotFound(err error) error {
+	var e pdnshttp.ErrUnprocessable
+	if errors.As(err, &e) && strings.Contains(e.Message, zoneNotFoundMessage) {
+		return pdnshttp.ErrNotFound{URL: e.URL}
 	}
 
-	return &zone, nil
+	return err
 }
@@ -0,0 +1,167 @@
+package zones
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const exampleZoneWithRecordSets = `{
+	"id": "example.com.",
+	"name": "example.com.",
+	"masters": [],
+	"rrsets": [
+		{"name": "example.com.", "type": "SOA", "ttl": 3600, "records": [{"content": "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600", "disabled": false}], "comments": []},
+		{"name": "www.example.com.", "type": "A", "ttl": 60, "records": [{"content": "192.0.2.1", "disabled": false}], "comments": [{"content": "web", "account": "alice", "modified_at": 1}]},
+		{"name": "mail.example.com.", "type": "A", "ttl": 60, "records": [{"content": "192.0.2.2", "disabled": true}], "comments": []}
+	],
+	"serial": 1,
+	"soa_edit_api": "DEFAULT"
+}`
+
+func TestIterateRecordSetsDecodesRecordSets(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/example.com.").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(exampleZoneWithRecordSets)
+
+	var sets []ResourceRecordSet
+
+	for set, err := range newTestClient().IterateRecordSets(context.Background(), "localhost", "example.com.") {
+		require.Nil(t, err)
+		sets = append(sets, set)
+	}
+
+	require.Len(t, sets, 3)
+	assert.Equal(t, "SOA", sets[0].Type)
+	assert.Equal(t, "www.example.com.", sets[1].Name)
+	assert.Equal(t, "web", sets[1].Comments[0].Content)
+	assert.True(t, sets[2].Records[0].Disabled)
+}
+
+func TestIterateRecordSetsCanStopEarly(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/example.com.").
+		MatchParam("rrset_name", "^www\\.example\\.com\\.$").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(exampleZoneWithRecordSets)
+
+	count := 0
+
+	for _, err := range newTestClient().IterateRecordSets(context.Background(), "localhost", "example.com.", WithResourceRecordSetFilter("www.example.com.", "A")) {
+		require.Nil(t, err)
+		count++
+		break
+	}
+
+	assert.Equal(t, 1, count)
+}
+
+func TestIterateRecordSetsReturnsNotFoundForUnknownZones(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/unknown.").
+		Reply(http.StatusUnprocessableEntity).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"error": "Could not find domain 'unknown.'"}`)
+
+	var errs []error
+
+	for _, err := range newTestClient().IterateRecordSets(context.Background(), "localhost", "unknown.") {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.True(t, pdnshttp.IsNotFound(errs[0]))
+}
+
+func TestWithoutCommentsRemovesComments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/example.com.").
+		Times(2).
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(exampleZoneWithRecordSets)
+
+	c := newTestClient()
+
+	zone, err := c.GetZone(context.Background(), "localhost", "example.com.", WithoutComments())
+	require.Nil(t, err)
+	assert.Nil(t, zone.GetRecordSet("www.example.com.", "A").Comments)
+
+	for set, err := range c.IterateRecordSets(context.Background(), "localhost", "example.com.", WithoutComments()) {
+		require.Nil(t, err)
+		assert.Nil(t, set.Comments)
+	}
+}
+
+func TestGetZoneReturnsNotFoundForUnknownZones(t *testing.T) {
+	defer gock.Off()
+
+	// this is the response body of PowerDNS 4.1 for unknown zones
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/unknown.example.").
+		Reply(http.StatusUnprocessableEntity).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"error": "Could not find domain 'unknown.example.'"}`)
+
+	zone, err := newTestClient().GetZone(context.Background(), "localhost", "unknown.example.")
+
+	assert.Nil(t, zone)
+	assert.True(t, pdnshttp.IsNotFound(err))
+	assert.False(t, pdnshttp.IsUnprocessable(err))
+}
+
+func TestGetZoneKeepsOtherUnprocessableErrors(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/example.com.").
+		Reply(http.StatusUnprocessableEntity).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"error": "Zone is corrupt"}`)
+
+	_, err := newTestClient().GetZone(context.Background(), "localhost", "example.com.")
+
+	require.NotNil(t, err)
+	assert.False(t, pdnshttp.IsNotFound(err))
+
+	var e pdnshttp.ErrUnprocessable
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, "Zone is corrupt", e.Message)
+}
+
+func TestIterateRecordSetsKeepsOtherUnprocessableErrors(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones/example.com.").
+		Reply(http.StatusUnprocessableEntity).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"error": "Zone is corrupt"}`)
+
+	var errs []error
+
+	for _, err := range newTestClient().IterateRecordSets(context.Background(), "localhost", "example.com.") {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.False(t, pdnshttp.IsNotFound(errs[0]))
+	assert.True(t, pdnshttp.IsUnprocessable(errs[0]))
+}
@@ -0,0 +1,116 @@
+package zones
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+// DefaultImportBatchSize is the number of record sets above which ImportZone
+// creates the zone first, and then adds the record sets in batches.
+const DefaultImportBatchSize = 1000
+
+// ImportSOAMode controls how ImportZone treats the SOA record of a master
+// file.
+type ImportSOAMode int
+
+const (
+	// ImportSOASkip ignores the SOA record of the master file; PowerDNS
+	// generates a default SOA record for the new zone.
+	ImportSOASkip ImportSOAMode = iota
+
+	// ImportSOAMerge uses the SOA record of the master file for the new zone.
+	ImportSOAMerge
+)
+
+// ImportZoneOptions configures ImportZone.
+type ImportZoneOptions struct {
+	// Kind is the kind of the new zone; defaults to ZoneKindNative.
+	Kind ZoneKind
+
+	// Account is the account of the new zone.
+	Account string
+
+	// SOA controls how the SOA record of the master file is treated.
+	SOA ImportSOAMode
+
+	// BatchSize is the maximum number of record sets that are sent in a
+	// single request; defaults to DefaultImportBatchSize. Larger zones are
+	// created empty first, and then filled using AddRecordSetsToZone; if one
+	// of these batches fails, the zone is deleted again.
+	BatchSize int
+}
+
+func (c *client) ImportZone(ctx context.Context, serverID string, name string, r io.Reader, opts ImportZoneOptions) (*Zone, error) {
+	parsed, err := ParseZoneFile(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := Zone{
+		Name:    parsed.Name,
+		Kind:    opts.Kind,
+		Account: opts.Account,
+	}
+
+	sets := make([]ResourceRecordSet, 0, len(parsed.ResourceRecordSets))
+
+	for _, set := range parsed.ResourceRecordSets {
+		if !dnsname.IsSubdomain(set.Name, zone.Name) {
+			return nil, fmt.Errorf("record set %s IN %s is outside of zone %s", set.Name, set.Type, zone.Name)
+		}
+
+		switch {
+		case set.Name == zone.Name && set.Type == "NS":
+			// PowerDNS does not accept both nameservers and an apex NS set
+			for _, rec := range set.Records {
+				zone.Nameservers = append(zone.Nameservers, rec.Content)
+			}
+
+			continue
+
+		case set.Name == zone.Name && set.Type == "SOA" && opts.SOA == ImportSOASkip:
+			continue
+		}
+
+		sets = append(sets, set)
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+
+	if len(sets) <= batchSize {
+		zone.ResourceRecordSets = sets
+		return c.CreateZone(ctx, serverID, zone)
+	}
+
+	created, err := c.CreateZone(ctx, serverID, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(sets); start += batchSize {
+		end := start + batchSize
+		if end > len(sets) {
+			end = len(sets)
+		}
+
+		if err := c.AddRecordSetsToZone(ctx, serverID, created.ID, sets[start:end]); err != nil {
+			err = fmt.Errorf("error while importing record sets %d to %d of zone %s: %w", start, end, created.Name, err)
+
+			// do not leave a partially imported zone behind, even if ctx was
+			// cancelled
+			if delErr := c.DeleteZone(context.WithoutCancel(ctx), serverID, created.ID); delErr != nil {
+				return nil, fmt.Errorf("%w; additionally, the partially imported zone could not be deleted: %s", err, delErr)
+			}
+
+			return nil, err
+		}
+	}
+
+	return c.GetZone(ctx, serverID, created.ID)
+}
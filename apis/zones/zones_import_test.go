@@ -0,0 +1,107 @@
+package zones_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/pdnstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importZoneFile = `$ORIGIN example.org.
+$TTL 3600
+@    IN SOA ns1.example.org. hostmaster.example.org. 2024050101 7200 3600 1209600 300
+@    IN NS  ns1
+@    IN NS  ns2.example.net.
+@    IN A   192.0.2.1
+www  300 IN CNAME @
+`
+
+func TestImportZoneCreatesZoneFromZoneFile(t *testing.T) {
+	srv := pdnstest.NewServer()
+	defer srv.Close()
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	created, err := c.Zones().ImportZone(context.Background(), "localhost", "example.org.", strings.NewReader(importZoneFile), zones.ImportZoneOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, "example.org.", created.Name)
+
+	z, _ := srv.Zone("example.org.")
+	require.NotNil(t, z.GetRecordSet("example.org.", "NS"))
+	assert.Equal(t, []zones.Record{{Content: "ns1.example.org."}, {Content: "ns2.example.net."}}, z.GetRecordSet("example.org.", "NS").Records)
+	assert.Equal(t, "example.org.", z.GetRecordSet("www.example.org.", "CNAME").Records[0].Content)
+	assert.NotContains(t, z.GetRecordSet("example.org.", "SOA").Records[0].Content, "2024050101")
+}
+
+func TestImportZoneMergesSOA(t *testing.T) {
+	srv := pdnstest.NewServer()
+	defer srv.Close()
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	_, err = c.Zones().ImportZone(context.Background(), "localhost", "example.org.", strings.NewReader(importZoneFile), zones.ImportZoneOptions{SOA: zones.ImportSOAMerge})
+	require.Nil(t, err)
+
+	z, _ := srv.Zone("example.org.")
+	assert.Equal(t, "ns1.example.org. hostmaster.example.org. 2024050101 7200 3600 1209600 300", z.GetRecordSet("example.org.", "SOA").Records[0].Content)
+}
+
+func TestImportZoneCreatesLargeZonesInBatches(t *testing.T) {
+	srv := pdnstest.NewServer()
+	defer srv.Close()
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	zoneFile := strings.Builder{}
+	zoneFile.WriteString(importZoneFile)
+
+	for i := 0; i < 25; i++ {
+		fmt.Fprintf(&zoneFile, "host%d IN A 192.0.2.%d\n", i, i+10)
+	}
+
+	imported, err := c.Zones().ImportZone(context.Background(), "localhost", "example.org.", strings.NewReader(zoneFile.String()), zones.ImportZoneOptions{BatchSize: 10})
+	require.Nil(t, err)
+
+	// 25 hosts, apex A, www CNAME, plus generated SOA and NS
+	assert.Len(t, imported.ResourceRecordSets, 29)
+	assert.NotNil(t, imported.GetRecordSet("host24.example.org.", "A"))
+}
+
+func TestImportZoneRejectsRecordsOutsideOfZone(t *testing.T) {
+	srv := pdnstest.NewServer()
+	defer srv.Close()
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	_, err = c.Zones().ImportZone(context.Background(), "localhost", "example.org.", strings.NewReader("www.example.com. 3600 IN A 192.0.2.1\n"), zones.ImportZoneOptions{})
+	assert.NotNil(t, err)
+
+	_, ok := srv.Zone("example.org.")
+	assert.False(t, ok)
+}
+
+func TestImportZoneDeletesPartiallyImportedZones(t *testing.T) {
+	srv := pdnstest.NewServer()
+	defer srv.Close()
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	// the CNAME conflicts with the A record, which is sent in another batch
+	zoneFile := importZoneFile + "www IN A 192.0.2.5\n"
+
+	_, err = c.Zones().ImportZone(context.Background(), "localhost", "example.org.", strings.NewReader(zoneFile), zones.ImportZoneOptions{BatchSize: 1})
+	require.NotNil(t, err)
+
+	_, ok := srv.Zone("example.org.")
+	assert.False(t, ok, "partially imported zone should have been deleted")
+}
@@ -0,0 +1,77 @@
+package zones_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/mittwald/go-powerdns/pdnstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSerialClientTest returns a client that may execute only one request at
+// a time, to verify that iterators do not block further requests.
+func setupSerialClientTest(t *testing.T) pdns.Client {
+	srv := pdnstest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := srv.NewClient(pdns.WithRateLimit(pdnshttp.RateLimit{MaxInFlight: 1}))
+	require.Nil(t, err)
+
+	for _, name := range []string{"example.com.", "example.org."} {
+		_, err := c.Zones().CreateZone(context.Background(), "localhost", zones.Zone{
+			Name:        name,
+			Nameservers: []string{"ns1.example.net."},
+			ResourceRecordSets: []zones.ResourceRecordSet{
+				{Name: "www." + name, Type: "A", TTL: 300, Records: []zones.Record{{Content: "192.0.2.1"}}},
+			},
+		})
+		require.Nil(t, err)
+	}
+
+	return c
+}
+
+func TestIterateZonesAllowsRequestsWhileIterating(t *testing.T) {
+	c := setupSerialClientTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count := 0
+
+	for zone, err := range c.Zones().IterateZones(ctx, "localhost", zones.ListZonesOptions{}) {
+		require.Nil(t, err)
+
+		_, err := c.Zones().GetZone(ctx, "localhost", zone.ID)
+		require.Nil(t, err)
+
+		count++
+	}
+
+	assert.Equal(t, 2, count)
+}
+
+func TestIterateRecordSetsAllowsRequestsWhileIterating(t *testing.T) {
+	c := setupSerialClientTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count := 0
+
+	for set, err := range c.Zones().IterateRecordSets(ctx, "localhost", "example.com.") {
+		require.Nil(t, err)
+
+		_, err := c.Zones().GetZone(ctx, "localhost", "example.com.", zones.WithResourceRecordSetFilter(set.Name, set.Type))
+		require.Nil(t, err)
+
+		count++
+	}
+
+	assert.True(t, count > 0)
+}
@@ -2,12 +2,52 @@ package zones
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"net/http"
 	"net/url"
 
+	"github.com/mittwald/go-powerdns/dnsname"
 	"github.com/mittwald/go-powerdns/pdnshttp"
 )
 
+// ListZonesOptions controls which zones are returned by ListZonesWithOptions
+// and IterateZones.
+type ListZonesOptions struct {
+	// Zone restricts the result to the zone with exactly this name. This
+	// filter is applied by the server.
+	Zone string
+
+	// Suffix restricts the result to zones that are equal to or below this
+	// name; for example, "example.com." matches "example.com." and
+	// "customer.example.com.". PowerDNS does not support this filter, so it is
+	// applied while decoding the response.
+	Suffix string
+
+	// Account restricts the result to zones of this account. PowerDNS does
+	// not support this filter, so it is applied while decoding the response.
+	Account string
+
+	// SkipDNSSEC causes the server not to look up the DNSSEC state of each
+	// zone, which is expensive for servers with many zones. The DNSSec and
+	// EditedSerial fields are not populated in this case.
+	SkipDNSSEC bool
+}
+
+func (o *ListZonesOptions) matches(z *Zone) bool {
+	if o.Suffix != "" && !dnsname.IsSubdomain(z.Name, o.Suffix) {
+		return false
+	}
+
+	if o.Account != "" && z.Account != o.Account {
+		return false
+	}
+
+	return true
+}
+
 func (c *client) ListZones(ctx context.Context, serverID string) ([]Zone, error) {
 	zones := make([]Zone, 0)
 	path := fmt.Sprintf("/servers/%s/zones", url.PathEscape(serverID))
@@ -31,3 +71,60 @@ func (c *client) ListZone(ctx context.Context, serverID string, zoneName string)
 
 	return zones, nil
 }
+
+func (c *client) ListZonesWithOptions(ctx context.Context, serverID string, opts ListZonesOptions) ([]Zone, error) {
+	zones := make([]Zone, 0)
+
+	for zone, err := range c.IterateZones(ctx, serverID, opts) {
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, zone)
+	}
+
+	return zones, nil
+}
+
+func (c *client) IterateZones(ctx context.Context, serverID string, opts ListZonesOptions) iter.Seq2[Zone, error] {
+	return func(yield func(Zone, error) bool) {
+		path := fmt.Sprintf("/servers/%s/zones", url.PathEscape(serverID))
+
+		req, err := c.httpClient.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			yield(Zone{}, err)
+			return
+		}
+
+		if opts.Zone != "" {
+			_ = pdnshttp.WithQueryValue("zone", dnsname.Normalize(opts.Zone))(req)
+		}
+
+		if opts.SkipDNSSEC {
+			_ = pdnshttp.WithQueryValue("dnssec", "false")(req)
+		}
+
+		err = c.httpClient.DoStream(ctx, req, func(res *http.Response) error {
+			return decodeJSONArray(json.NewDecoder(res.Body), func(dec *json.Decoder) error {
+				zone := Zone{}
+				if err := dec.Decode(&zone); err != nil {
+					return err
+				}
+
+				if !opts.matches(&zone) {
+					return nil
+				}
+
+				if !yield(zone, nil) {
+					return errStopDecoding
+				}
+
+				return nil
+			})
+		})
+
+		if err != nil && !errors.Is(err, errStopDecoding) {
+			yield(Zone{}, err)
+		}
+	}
+}
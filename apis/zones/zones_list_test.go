@@ -0,0 +1,142 @@
+package zones
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const exampleZoneList = `[
+	{"id": "example.com.", "name": "example.com.", "kind": "Native", "account": "alice", "serial": 1},
+	{"id": "customer.example.com.", "name": "customer.example.com.", "kind": "Native", "account": "bob", "serial": 2},
+	{"id": "example.org.", "name": "example.org.", "kind": "Native", "account": "alice", "serial": 3}
+]`
+
+func newTestClient() Client {
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := pdnshttp.NewClient("http://dns.example", hc, &pdnshttp.APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+
+	return New(c)
+}
+
+func zoneNames(zones []Zone) []string {
+	names := make([]string, len(zones))
+	for i := range zones {
+		names[i] = zones[i].Name
+	}
+
+	return names
+}
+
+func TestListZonesWithOptionsSendsServerSideFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones").
+		MatchParam("zone", "^example\\.com\\.$").
+		MatchParam("dnssec", "^false$").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`[{"id": "example.com.", "name": "example.com.", "kind": "Native"}]`)
+
+	zones, err := newTestClient().ListZonesWithOptions(context.Background(), "localhost", ListZonesOptions{
+		Zone:       "Example.COM",
+		SkipDNSSEC: true,
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.com."}, zoneNames(zones))
+	assert.True(t, gock.IsDone())
+}
+
+func TestListZonesWithOptionsFiltersBySuffixAndAccount(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones").
+		Times(3).
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(exampleZoneList)
+
+	c := newTestClient()
+
+	zones, err := c.ListZonesWithOptions(context.Background(), "localhost", ListZonesOptions{Suffix: "example.com"})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.com.", "customer.example.com."}, zoneNames(zones))
+
+	zones, err = c.ListZonesWithOptions(context.Background(), "localhost", ListZonesOptions{Account: "alice"})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.com.", "example.org."}, zoneNames(zones))
+
+	zones, err = c.ListZonesWithOptions(context.Background(), "localhost", ListZonesOptions{Suffix: "example.com.", Account: "alice"})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.com."}, zoneNames(zones))
+}
+
+func TestIterateZonesCanStopEarly(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(exampleZoneList)
+
+	names := []string{}
+
+	for zone, err := range newTestClient().IterateZones(context.Background(), "localhost", ListZonesOptions{}) {
+		require.Nil(t, err)
+		names = append(names, zone.Name)
+
+		if len(names) == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"example.com.", "customer.example.com."}, names)
+}
+
+func TestIterateZonesYieldsDecodingErrors(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/localhost/zones").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`[{"id": "example.com.", "name": "example.com."}, {"name": `)
+
+	var names []string
+	var errs []error
+
+	for zone, err := range newTestClient().IterateZones(context.Background(), "localhost", ListZonesOptions{}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		names = append(names, zone.Name)
+	}
+
+	assert.Equal(t, []string{"example.com."}, names)
+	assert.Len(t, errs, 1)
+}
+
+func TestIterateZonesYieldsResponseErrors(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://dns.example").
+		Get("/api/v1/servers/unknown/zones").
+		Reply(http.StatusNotFound)
+
+	_, err := newTestClient().ListZonesWithOptions(context.Background(), "unknown", ListZonesOptions{})
+
+	require.NotNil(t, err)
+	assert.True(t, pdnshttp.IsNotFound(err))
+}
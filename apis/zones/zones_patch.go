@@ -0,0 +1,25 @@
+package zones
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
+)
+
+func (c *client) PatchZone(ctx context.Context, serverID string, zoneID string, sets []ResourceRecordSet) error {
+	path := fmt.Sprintf("/servers/%s/zones/%s", url.PathEscape(serverID), url.PathEscape(zoneID))
+
+	for idx := range sets {
+		if sets[idx].ChangeType == 0 {
+			return fmt.Errorf("record set %s IN %s has no change type", sets[idx].Name, sets[idx].Type)
+		}
+	}
+
+	patch := Zone{
+		ResourceRecordSets: sets,
+	}
+
+	return c.httpClient.Patch(ctx, path, nil, pdnshttp.WithJSONRequestBody(&patch))
+}
@@ -0,0 +1,13 @@
+package zones
+
+import "context"
+
+func (c *client) AddRecordsToSet(ctx context.Context, serverID string, zoneID string, set ResourceRecordSet) error {
+	set.ChangeType = ChangeTypeExtend
+	return c.PatchZone(ctx, serverID, zoneID, []ResourceRecordSet{set})
+}
+
+func (c *client) RemoveRecordsFromSet(ctx context.Context, serverID string, zoneID string, set ResourceRecordSet) error {
+	set.ChangeType = ChangeTypePrune
+	return c.PatchZone(ctx, serverID, zoneID, []ResourceRecordSet{set})
+}
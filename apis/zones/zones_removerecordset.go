@@ -1,12 +1,6 @@
 package zones
 
-import (
-	"context"
-	"fmt"
-	"net/url"
-
-	"github.com/mittwald/go-powerdns/pdnshttp"
-)
+import "context"
 
 func (c *client) RemoveRecordSetFromZone(ctx context.Context, serverID string, zoneID string, name string, recordType string) error {
 	set := ResourceRecordSet{
@@ -19,14 +13,9 @@ func (c *client) RemoveRecordSetFromZone(ctx context.Context, serverID string, z
 }
 
 func (c *client) RemoveRecordSetsFromZone(ctx context.Context, serverID string, zoneID string, sets []ResourceRecordSet) error {
-	path := fmt.Sprintf("/servers/%s/zones/%s", url.PathEscape(serverID), url.PathEscape(zoneID))
-
 	for idx := range sets {
 		sets[idx].ChangeType = ChangeTypeDelete
 	}
-	patch := Zone{
-		ResourceRecordSets: sets,
-	}
 
-	return c.httpClient.Patch(ctx, path, nil, pdnshttp.WithJSONRequestBody(&patch))
+	return c.PatchZone(ctx, serverID, zoneID, sets)
 }
@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/mittwald/go-powerdns/apis/autoprimaries"
 	"github.com/mittwald/go-powerdns/apis/cache"
 	"github.com/mittwald/go-powerdns/apis/cryptokeys"
 	"github.com/mittwald/go-powerdns/apis/metadata"
 	"github.com/mittwald/go-powerdns/apis/networks"
 	"github.com/mittwald/go-powerdns/apis/search"
 	"github.com/mittwald/go-powerdns/apis/servers"
+	"github.com/mittwald/go-powerdns/apis/statistics"
 	"github.com/mittwald/go-powerdns/apis/tsigkey"
 	"github.com/mittwald/go-powerdns/apis/views"
 	"github.com/mittwald/go-powerdns/apis/zones"
@@ -24,16 +26,19 @@ type client struct {
 	httpClient    *http.Client
 	authenticator pdnshttp.ClientAuthenticator
 	debugOutput   io.Writer
-
-	cache      cache.Client
-	cryptokeys cryptokeys.Client
-	metadata   metadata.Client
-	search     search.Client
-	networks   networks.Client
-	servers    servers.Client
-	tsigkey    tsigkey.Client
-	views      views.Client
-	zones      zones.Client
+	httpOptions   []pdnshttp.ClientOption
+
+	autoprimaries autoprimaries.Client
+	cache         cache.Client
+	cryptokeys    cryptokeys.Client
+	metadata      metadata.Client
+	search        search.Client
+	networks      networks.Client
+	servers       servers.Client
+	statistics    statistics.Client
+	tsigkey       tsigkey.Client
+	views         views.Client
+	zones         zones.Client
 }
 
 type ClientOption func(c *client) error
@@ -61,7 +66,7 @@ func New(opt ...ClientOption) (Client, error) {
 		}
 	}
 
-	hc := pdnshttp.NewClient(c.baseURL, c.httpClient, c.authenticator, c.debugOutput)
+	hc := pdnshttp.NewClient(c.baseURL, c.httpClient, c.authenticator, c.debugOutput, c.httpOptions...)
 
 	c.servers = servers.New(hc)
 	c.zones = zones.New(hc)
@@ -72,6 +77,8 @@ func New(opt ...ClientOption) (Client, error) {
 	c.views = views.New(hc)
 	c.networks = networks.New(hc)
 	c.tsigkey = tsigkey.New(hc)
+	c.statistics = statistics.New(hc)
+	c.autoprimaries = autoprimaries.New(hc)
 
 	return &c, nil
 }
@@ -153,3 +160,7 @@ func (c *client) Networks() networks.Client { return c.networks }
 func (c *client) Views() views.Client { return c.views }
 
 func (c *client) TsigKeys() tsigkey.Client { return c.tsigkey }
+
+func (c *client) Statistics() statistics.Client { return c.statistics }
+
+func (c *client) Autoprimaries() autoprimaries.Client { return c.autoprimaries }
@@ -7,12 +7,15 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/mittwald/go-powerdns/apis/autoprimaries"
 	"github.com/mittwald/go-powerdns/apis/metadata"
 	"github.com/mittwald/go-powerdns/apis/networks"
 	"github.com/mittwald/go-powerdns/apis/search"
+	"github.com/mittwald/go-powerdns/apis/statistics"
 	"github.com/mittwald/go-powerdns/apis/tsigkey"
 	"github.com/mittwald/go-powerdns/apis/zones"
 	"github.com/mittwald/go-powerdns/pdnshttp"
@@ -20,12 +23,23 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// integrationTests is false if the integration tests, which require a
+// PowerDNS server, are skipped.
+var integrationTests = true
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 
 	if testing.Short() {
 		fmt.Println("skipping integration tests")
-		os.Exit(0)
+		integrationTests = false
+
+		// examples cannot be skipped individually, and require a server
+		if f := flag.Lookup("test.skip"); f != nil && f.Value.String() == "" {
+			_ = flag.Set("test.skip", "^Example")
+		}
+
+		os.Exit(m.Run())
 	}
 
 	runOrPanic("docker", "compose", "rm", "-sfv")
@@ -167,6 +181,7 @@ func TestCreateZoneProducedReadableErrorMessages(t *testing.T) {
 	_, err2 := c.Zones().CreateZone(ctx, "localhost", zone)
 	require.Error(t, err2, "CreateZone should return error")
 	require.Equal(t, "unexpected status code 409: http://localhost:8081/api/v1/servers/localhost/zones Conflict", err2.Error())
+	require.True(t, pdnshttp.IsConflict(err2), "CreateZone should return conflict error")
 }
 
 func TestDeleteZone(t *testing.T) {
@@ -820,7 +835,211 @@ func TestTSIGKeyLifecycle(t *testing.T) {
 	assert.True(t, pdnshttp.IsNotFound(err))
 }
 
+func TestGetStatistics(t *testing.T) {
+	c := buildClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := c.Statistics().GetStatistics(ctx, "localhost", statistics.WithoutRings())
+	require.NoError(t, err, "GetStatistics returned error")
+	require.NotEmpty(t, stats)
+
+	_, ok := stats.Counter("uptime")
+	assert.True(t, ok, "uptime counter should be present")
+
+	uptime, err := c.Statistics().GetStatistic(ctx, "localhost", "uptime")
+	require.NoError(t, err, "GetStatistic returned error")
+	assert.IsType(t, statistics.StatisticItem{}, uptime)
+
+	_, err = c.Statistics().GetStatistic(ctx, "localhost", "does-not-exist")
+	assert.True(t, pdnshttp.IsNotFound(err))
+}
+
+func TestListServerConfig(t *testing.T) {
+	c := buildClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	settings, err := c.Servers().ListConfig(ctx, "localhost")
+	require.NoError(t, err, "ListConfig returned error")
+	require.NotEmpty(t, settings)
+
+	_, ok := settings.Get("api")
+	assert.True(t, ok, "api setting should be present")
+}
+
+func TestAutoprimaryLifecycle(t *testing.T) {
+	c := buildClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	input := autoprimaries.Autoprimary{
+		IP:         "192.0.2.53",
+		Nameserver: "ns1.autoprimary.example.",
+		Account:    "integration",
+	}
+
+	err := c.Autoprimaries().CreateAutoprimary(ctx, "localhost", input)
+	require.NoError(t, err, "CreateAutoprimary returned error")
+
+	t.Cleanup(func() {
+		_ = c.Autoprimaries().DeleteAutoprimary(context.Background(), "localhost", input.IP, input.Nameserver)
+	})
+
+	listed, err := c.Autoprimaries().ListAutoprimaries(ctx, "localhost")
+	require.NoError(t, err, "ListAutoprimaries returned error")
+	require.Contains(t, listed, input)
+
+	err = c.Autoprimaries().DeleteAutoprimary(ctx, "localhost", input.IP, input.Nameserver)
+	require.NoError(t, err, "DeleteAutoprimary returned error")
+
+	listed, err = c.Autoprimaries().ListAutoprimaries(ctx, "localhost")
+	require.NoError(t, err)
+	require.NotContains(t, listed, input)
+}
+
+func TestImportZone(t *testing.T) {
+	c := buildClient(t)
+
+	zoneFile := "$ORIGIN example-import.de.\n" +
+		"$TTL 3600\n" +
+		"@    IN NS  ns1.example.com.\n" +
+		"@    IN NS  ns2.example.com.\n" +
+		"www  60 IN A 127.0.0.1\n"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	created, err := c.Zones().ImportZone(ctx, "localhost", "example-import.de.", strings.NewReader(zoneFile), zones.ImportZoneOptions{})
+	require.Nil(t, err, "ImportZone returned error")
+
+	zone, err := c.Zones().GetZone(ctx, "localhost", created.ID)
+	require.Nil(t, err, "GetZone returned error")
+
+	require.NotNil(t, zone.GetRecordSet("www.example-import.de.", "A"))
+	assert.Equal(t, 60, zone.GetRecordSet("www.example-import.de.", "A").TTL)
+	assert.Len(t, zone.GetRecordSet("example-import.de.", "NS").Records, 2)
+}
+
+func TestAddAndRemoveRecordsInSet(t *testing.T) {
+	c := buildClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	created, err := c.Zones().CreateZone(ctx, "localhost", zones.Zone{
+		Name:        "example-extend.de.",
+		Type:        zones.ZoneTypeZone,
+		Kind:        zones.ZoneKindNative,
+		Nameservers: []string{"ns1.example.com.", "ns2.example.com."},
+		ResourceRecordSets: []zones.ResourceRecordSet{
+			{Name: "www.example-extend.de.", Type: "A", TTL: 60, Records: []zones.Record{{Content: "127.0.0.1"}}},
+		},
+	})
+	require.Nil(t, err, "CreateZone returned error")
+
+	err = c.Zones().AddRecordsToSet(ctx, "localhost", created.ID, zones.ResourceRecordSet{
+		Name:    "www.example-extend.de.",
+		Type:    "A",
+		TTL:     60,
+		Records: []zones.Record{{Content: "127.0.0.2"}},
+	})
+	require.Nil(t, err, "AddRecordsToSet returned error")
+
+	zone, err := c.Zones().GetZone(ctx, "localhost", created.ID)
+	require.Nil(t, err)
+	require.Len(t, zone.GetRecordSet("www.example-extend.de.", "A").Records, 2)
+
+	err = c.Zones().RemoveRecordsFromSet(ctx, "localhost", created.ID, zones.ResourceRecordSet{
+		Name:    "www.example-extend.de.",
+		Type:    "A",
+		Records: []zones.Record{{Content: "127.0.0.1"}},
+	})
+	require.Nil(t, err, "RemoveRecordsFromSet returned error")
+
+	zone, err = c.Zones().GetZone(ctx, "localhost", created.ID)
+	require.Nil(t, err)
+	assert.Equal(t, []zones.Record{{Content: "127.0.0.2"}}, zone.GetRecordSet("www.example-extend.de.", "A").Records)
+}
+
+func TestListZonesWithOptions(t *testing.T) {
+	c := buildClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := c.Zones().CreateZone(ctx, "localhost", zones.Zone{
+		Name:        "example-list.de.",
+		Type:        zones.ZoneTypeZone,
+		Kind:        zones.ZoneKindNative,
+		Account:     "list-test",
+		Nameservers: []string{"ns1.example.com.", "ns2.example.com."},
+	})
+	require.Nil(t, err, "CreateZone returned error")
+
+	listed, err := c.Zones().ListZonesWithOptions(ctx, "localhost", zones.ListZonesOptions{Zone: "example-list.de.", SkipDNSSEC: true})
+	require.Nil(t, err, "ListZonesWithOptions returned error")
+	require.Len(t, listed, 1)
+	assert.Equal(t, "example-list.de.", listed[0].Name)
+
+	count := 0
+	for zone, err := range c.Zones().IterateZones(ctx, "localhost", zones.ListZonesOptions{Account: "list-test"}) {
+		require.Nil(t, err, "IterateZones returned error")
+		assert.Equal(t, "example-list.de.", zone.Name)
+		count++
+	}
+
+	assert.Equal(t, 1, count)
+}
+
+func TestRecordSetCommentManagement(t *testing.T) {
+	c := buildClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	created, err := c.Zones().CreateZone(ctx, "localhost", zones.Zone{
+		Name:        "example-comments.de.",
+		Type:        zones.ZoneTypeZone,
+		Kind:        zones.ZoneKindNative,
+		Nameservers: []string{"ns1.example.com.", "ns2.example.com."},
+		ResourceRecordSets: []zones.ResourceRecordSet{
+			{Name: "www.example-comments.de.", Type: "A", TTL: 60, Records: []zones.Record{{Content: "127.0.0.1"}}},
+		},
+	})
+	require.Nil(t, err, "CreateZone returned error")
+
+	err = c.Zones().AddCommentsToRecordSet(ctx, "localhost", created.ID, "www.example-comments.de.", "A", zones.Comment{Content: "created by test", Account: "test"})
+	require.Nil(t, err, "AddCommentsToRecordSet returned error")
+
+	zone, err := c.Zones().GetZone(ctx, "localhost", created.ID)
+	require.Nil(t, err, "GetZone returned error")
+
+	set := zone.GetRecordSet("www.example-comments.de.", "A")
+	require.NotNil(t, set)
+	require.Len(t, set.Comments, 1)
+	assert.Equal(t, "created by test", set.Comments[0].Content)
+	assert.False(t, set.Comments[0].ModifiedAt.IsZero())
+	assert.Len(t, set.Records, 1)
+
+	err = c.Zones().ClearRecordSetComments(ctx, "localhost", created.ID, "www.example-comments.de.", "A")
+	require.Nil(t, err, "ClearRecordSetComments returned error")
+
+	zone, err = c.Zones().GetZone(ctx, "localhost", created.ID)
+	require.Nil(t, err, "GetZone returned error")
+
+	assert.Empty(t, zone.GetRecordSet("www.example-comments.de.", "A").Comments)
+	assert.Len(t, zone.GetRecordSet("www.example-comments.de.", "A").Records, 1)
+}
+
 func buildClient(t *testing.T) Client {
+	if !integrationTests {
+		t.Skip("integration test; requires a PowerDNS server")
+	}
+
 	debug := io.Discard
 
 	if testing.Verbose() {
@@ -0,0 +1,380 @@
+package dnsname
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// ErrInvalidName is returned (wrapped) for syntactically invalid domain names.
+var ErrInvalidName = errors.New("invalid domain name")
+
+const (
+	// MaxLabelLength is the maximum length of a single label, in bytes.
+	MaxLabelLength = 63
+
+	// MaxNameLength is the maximum length of a domain name in wire format
+	// (including length bytes and the terminating root label).
+	MaxNameLength = 255
+)
+
+// Root is the name of the DNS root.
+const Root = "."
+
+// label is a single, unescaped label of a domain name.
+type label struct {
+	data []byte
+
+	// unicode is set if the label contained unescaped non-ASCII characters,
+	// which are subject to IDNA conversion.
+	unicode bool
+}
+
+// parse splits a name in presentation format into unescaped labels. The root
+// name has no labels.
+func parse(name string) ([]label, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: empty name", ErrInvalidName)
+	}
+
+	if name == Root {
+		return nil, nil
+	}
+
+	var (
+		labels  []label
+		current label
+	)
+
+	absolute := false
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+
+		switch {
+		case c == '.':
+			if len(current.data) == 0 {
+				return nil, fmt.Errorf("%w: %q contains an empty label", ErrInvalidName, name)
+			}
+
+			labels = append(labels, current)
+			current = label{}
+			absolute = i == len(name)-1
+
+		case c == '\\':
+			if i+1 >= len(name) {
+				return nil, fmt.Errorf("%w: %q ends with an incomplete escape sequence", ErrInvalidName, name)
+			}
+
+			if isDigit(name[i+1]) {
+				if i+3 >= len(name) || !isDigit(name[i+2]) || !isDigit(name[i+3]) {
+					return nil, fmt.Errorf("%w: %q contains an invalid escape sequence", ErrInvalidName, name)
+				}
+
+				v := int(name[i+1]-'0')*100 + int(name[i+2]-'0')*10 + int(name[i+3]-'0')
+				if v > 255 {
+					return nil, fmt.Errorf("%w: %q contains an invalid escape sequence", ErrInvalidName, name)
+				}
+
+				current.data = append(current.data, byte(v))
+				i += 3
+				continue
+			}
+
+			current.data = append(current.data, name[i+1])
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '"':
+			return nil, fmt.Errorf("%w: %q contains invalid characters", ErrInvalidName, name)
+
+		default:
+			if c >= utf8.RuneSelf {
+				current.unicode = true
+			}
+
+			current.data = append(current.data, c)
+		}
+	}
+
+	if !absolute {
+		labels = append(labels, current)
+	}
+
+	return labels, nil
+}
+
+// toASCII converts labels with non-ASCII characters into their IDNA ASCII
+// form, and checks label and name lengths.
+func toASCII(name string, labels []label) error {
+	length := 1
+
+	for i := range labels {
+		if labels[i].unicode {
+			if !utf8.Valid(labels[i].data) {
+				return fmt.Errorf("%w: %q is not valid UTF-8", ErrInvalidName, name)
+			}
+
+			ascii, err := idna.Lookup.ToASCII(string(labels[i].data))
+			if err != nil {
+				return fmt.Errorf("%w: %q: %s", ErrInvalidName, name, err)
+			}
+
+			labels[i] = label{data: []byte(ascii)}
+		}
+
+		if len(labels[i].data) > MaxLabelLength {
+			return fmt.Errorf("%w: %q contains a label longer than %d bytes", ErrInvalidName, name, MaxLabelLength)
+		}
+
+		length += len(labels[i].data) + 1
+	}
+
+	if length > MaxNameLength {
+		return fmt.Errorf("%w: %q is longer than %d bytes", ErrInvalidName, name, MaxNameLength)
+	}
+
+	return nil
+}
+
+// format renders labels as lowercase, absolute name in presentation format,
+// escaping special characters the same way as PowerDNS does.
+func format(labels []label) string {
+	if len(labels) == 0 {
+		return Root
+	}
+
+	out := strings.Builder{}
+
+	for _, l := range labels {
+		for _, c := range bytes.ToLower(l.data) {
+			switch {
+			case c == '.' || c == '\\':
+				out.WriteByte('\\')
+				out.WriteByte(c)
+			case c <= ' ' || c > '~':
+				fmt.Fprintf(&out, "\\%03d", c)
+			default:
+				out.WriteByte(c)
+			}
+		}
+
+		out.WriteByte('.')
+	}
+
+	return out.String()
+}
+
+// Canonicalize converts a domain name into the canonical form expected by
+// PowerDNS: lowercase, absolute (with a trailing dot; relative names are
+// considered to be relative to the root), with internationalized labels
+// converted to punycode, and with a uniform escaping of special characters.
+func Canonicalize(name string) (string, error) {
+	labels, err := parse(name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := toASCII(name, labels); err != nil {
+		return "", err
+	}
+
+	return format(labels), nil
+}
+
+// Normalize works like Canonicalize, but never fails: names that are invalid
+// are lowercased and given a trailing dot. Use it for comparing names that
+// were not validated before.
+func Normalize(name string) string {
+	if canonical, err := Canonicalize(name); err == nil {
+		return canonical
+	}
+
+	name = strings.ToLower(name)
+	if !IsAbsolute(name) {
+		name += "."
+	}
+
+	return name
+}
+
+// Validate checks that name is a syntactically valid domain name, either
+// absolute or relative.
+func Validate(name string) error {
+	_, err := Canonicalize(name)
+	return err
+}
+
+// IsAbsolute returns true if name ends with an unescaped dot.
+func IsAbsolute(name string) bool {
+	if !strings.HasSuffix(name, ".") {
+		return false
+	}
+
+	// count the backslashes before the trailing dot; an odd number means that
+	// the dot is escaped
+	backslashes := 0
+	for i := len(name) - 2; i >= 0 && name[i] == '\\'; i-- {
+		backslashes++
+	}
+
+	return backslashes%2 == 0
+}
+
+// Equal returns true if two domain names are equal, regardless of case and
+// trailing dots.
+func Equal(a, b string) bool {
+	return Normalize(a) == Normalize(b)
+}
+
+// IsSubdomain returns true if name is equal to parent or below it. It returns
+// false if either name is invalid.
+func IsSubdomain(name, parent string) bool {
+	n, err := canonicalLabels(name)
+	if err != nil {
+		return false
+	}
+
+	p, err := canonicalLabels(parent)
+	if err != nil {
+		return false
+	}
+
+	if len(n) < len(p) {
+		return false
+	}
+
+	offset := len(n) - len(p)
+
+	for i := range p {
+		if !bytes.Equal(n[offset+i].data, p[i].data) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Compare compares two domain names in canonical DNS order (RFC 4034,
+// section 6.1): label by label from the right, case-insensitively. It returns
+// a negative number if a sorts before b, a positive number if a sorts after
+// b, and zero if both are equal. Invalid names are compared as strings, after
+// all valid names.
+func Compare(a, b string) int {
+	la, errA := canonicalLabels(a)
+	lb, errB := canonicalLabels(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+
+	for i := 1; i <= len(la) && i <= len(lb); i++ {
+		if c := bytes.Compare(la[len(la)-i].data, lb[len(lb)-i].data); c != 0 {
+			return c
+		}
+	}
+
+	return len(la) - len(lb)
+}
+
+// MakeAbsolute converts a name, which may be relative to origin, into a
+// canonical absolute name. "@" denotes the origin itself; names that are
+// already absolute are only canonicalized.
+func MakeAbsolute(name, origin string) (string, error) {
+	if name == "@" {
+		return Canonicalize(origin)
+	}
+
+	if IsAbsolute(name) {
+		return Canonicalize(name)
+	}
+
+	if err := Validate(name); err != nil {
+		return "", err
+	}
+
+	if !IsAbsolute(origin) {
+		return "", fmt.Errorf("%w: origin %q is not absolute", ErrInvalidName, origin)
+	}
+
+	if origin == Root {
+		return Canonicalize(name + ".")
+	}
+
+	return Canonicalize(name + "." + origin)
+}
+
+// MakeRelative converts an absolute name into a name relative to origin.
+// The origin itself is returned as "@"; names outside of origin are returned
+// as canonical absolute names.
+func MakeRelative(name, origin string) (string, error) {
+	n, err := canonicalLabels(name)
+	if err != nil {
+		return "", err
+	}
+
+	if !IsSubdomain(name, origin) {
+		return format(n), nil
+	}
+
+	o, err := canonicalLabels(origin)
+	if err != nil {
+		return "", err
+	}
+
+	if len(n) == len(o) {
+		return "@", nil
+	}
+
+	return strings.TrimSuffix(format(n[:len(n)-len(o)]), "."), nil
+}
+
+// ToUnicode converts the punycode labels of a name into their Unicode form,
+// for display purposes.
+func ToUnicode(name string) (string, error) {
+	canonical, err := Canonicalize(name)
+	if err != nil {
+		return "", err
+	}
+
+	if canonical == Root {
+		return canonical, nil
+	}
+
+	unicode, err := idna.Display.ToUnicode(strings.TrimSuffix(canonical, "."))
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %s", ErrInvalidName, name, err)
+	}
+
+	return unicode + ".", nil
+}
+
+// canonicalLabels returns the lowercase, ASCII labels of a name.
+func canonicalLabels(name string) ([]label, error) {
+	labels, err := parse(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := toASCII(name, labels); err != nil {
+		return nil, err
+	}
+
+	for i := range labels {
+		labels[i].data = bytes.ToLower(labels[i].data)
+	}
+
+	return labels, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
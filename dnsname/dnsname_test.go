@@ -0,0 +1,174 @@
+package dnsname
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	cases := map[string]string{
+		".":                     ".",
+		"example.com":           "example.com.",
+		"WWW.Example.COM.":      "www.example.com.",
+		"_dmarc.example.com.":   "_dmarc.example.com.",
+		"bücher.example":        "xn--bcher-kva.example.",
+		"BÜCHER.example.":       "xn--bcher-kva.example.",
+		"xn--bcher-kva.example": "xn--bcher-kva.example.",
+		`a\.b.example.`:         `a\.b.example.`,
+		`a\046b.example.`:       `a\.b.example.`,
+		`\065bc.example.`:       "abc.example.",
+		`a\032b.example.`:       `a\032b.example.`,
+		`a\ b.example.`:         `a\032b.example.`,
+		`a\\b.example.`:         `a\\b.example.`,
+		"*.example.com.":        "*.example.com.",
+	}
+
+	for in, expected := range cases {
+		t.Run(in, func(t *testing.T) {
+			out, err := Canonicalize(in)
+			require.Nil(t, err)
+			assert.Equal(t, expected, out)
+
+			again, err := Canonicalize(out)
+			require.Nil(t, err)
+			assert.Equal(t, out, again)
+		})
+	}
+}
+
+func TestCanonicalizeRejectsInvalidNames(t *testing.T) {
+	cases := []string{
+		"",
+		"..",
+		"a..b.",
+		".example.com.",
+		"a b.example.",
+		`a\`,
+		`a\25.example.`,
+		`a\256.example.`,
+		strings.Repeat("a", 64) + ".example.",
+		strings.Repeat(strings.Repeat("a", 63)+".", 4),
+	}
+
+	for _, in := range cases {
+		t.Run(in, func(t *testing.T) {
+			_, err := Canonicalize(in)
+			require.NotNil(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidName))
+		})
+	}
+}
+
+func TestValidateAcceptsMaximumLength(t *testing.T) {
+	name := strings.Repeat(strings.Repeat("a", 63)+".", 3) + strings.Repeat("a", 61) + "."
+	assert.Nil(t, Validate(name))
+}
+
+func TestNormalizeFallsBackForInvalidNames(t *testing.T) {
+	assert.Equal(t, "www.example.com.", Normalize("WWW.example.com"))
+	assert.Equal(t, "a..b.", Normalize("A..b"))
+}
+
+func TestIsAbsolute(t *testing.T) {
+	assert.True(t, IsAbsolute("example.com."))
+	assert.True(t, IsAbsolute("."))
+	assert.True(t, IsAbsolute(`a\\.`))
+	assert.False(t, IsAbsolute("example.com"))
+	assert.False(t, IsAbsolute(`a\.`))
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal("WWW.example.com", "www.example.com."))
+	assert.True(t, Equal("bücher.example.", "xn--bcher-kva.example."))
+	assert.False(t, Equal("www.example.com.", "example.com."))
+	assert.False(t, Equal(`a\.b.example.`, "a.b.example."))
+}
+
+func TestIsSubdomain(t *testing.T) {
+	assert.True(t, IsSubdomain("www.example.com.", "example.com."))
+	assert.True(t, IsSubdomain("WWW.Example.com", "example.COM."))
+	assert.True(t, IsSubdomain("example.com.", "example.com."))
+	assert.True(t, IsSubdomain("example.com.", "."))
+	assert.False(t, IsSubdomain("wwwexample.com.", "example.com."))
+	assert.False(t, IsSubdomain("example.com.", "www.example.com."))
+	assert.False(t, IsSubdomain(`www\.example.com.`, "www.example.com."))
+	assert.False(t, IsSubdomain("a..b.", "b."))
+}
+
+func TestCompareSortsInCanonicalOrder(t *testing.T) {
+	// example from RFC 4034, section 6.1
+	expected := []string{
+		"example.",
+		"a.example.",
+		"yljkjljk.a.example.",
+		"Z.a.example.",
+		"zABC.a.EXAMPLE.",
+		"z.example.",
+		`\001.z.example.`,
+		"*.z.example.",
+		`\200.z.example.`,
+	}
+
+	names := append([]string{}, expected...)
+	sort.Slice(names, func(i, j int) bool { return names[i] > names[j] })
+	sort.SliceStable(names, func(i, j int) bool { return Compare(names[i], names[j]) < 0 })
+
+	assert.Equal(t, expected, names)
+	assert.Equal(t, 0, Compare("Example.com", "example.com."))
+}
+
+func TestMakeAbsolute(t *testing.T) {
+	cases := []struct {
+		name, origin, expected string
+	}{
+		{"@", "example.com.", "example.com."},
+		{"www", "example.com.", "www.example.com."},
+		{"WWW.sub", "Example.com.", "www.sub.example.com."},
+		{"www.other.org.", "example.com.", "www.other.org."},
+		{"www", ".", "www."},
+		{`a\.b`, "example.com.", `a\.b.example.com.`},
+	}
+
+	for _, c := range cases {
+		out, err := MakeAbsolute(c.name, c.origin)
+		require.Nil(t, err)
+		assert.Equal(t, c.expected, out)
+	}
+
+	_, err := MakeAbsolute("www", "example.com")
+	assert.True(t, errors.Is(err, ErrInvalidName))
+}
+
+func TestMakeRelative(t *testing.T) {
+	cases := []struct {
+		name, origin, expected string
+	}{
+		{"example.com.", "example.com.", "@"},
+		{"WWW.example.com.", "example.com.", "www"},
+		{"a.b.example.com.", "example.com.", "a.b"},
+		{`a\.b.example.com.`, "example.com.", `a\.b`},
+		{"www.other.org.", "example.com.", "www.other.org."},
+		{"www.example.com.", ".", "www.example.com"},
+	}
+
+	for _, c := range cases {
+		out, err := MakeRelative(c.name, c.origin)
+		require.Nil(t, err)
+		assert.Equal(t, c.expected, out)
+
+		back, err := MakeAbsolute(out, c.origin)
+		require.Nil(t, err)
+		assert.Equal(t, Normalize(c.name), back)
+	}
+}
+
+func TestToUnicode(t *testing.T) {
+	out, err := ToUnicode("xn--bcher-kva.Example.")
+	require.Nil(t, err)
+	assert.Equal(t, "bücher.example.", out)
+}
@@ -0,0 +1,19 @@
+// Package dnsname contains utilities for handling domain names in
+// presentation format, as used by the PowerDNS API: canonicalization,
+// validation, comparison and conversion between relative and absolute names.
+//
+// PowerDNS expects domain names to be absolute (with a trailing dot) and
+// treats them case-insensitively; internationalized domain names need to be
+// converted to their ASCII ("punycode") form. Use Canonicalize to convert a
+// name into this form, and Equal to compare names:
+//
+//	name, err := dnsname.Canonicalize("WWW.Bücher.example")
+//	// name == "www.xn--bcher-kva.example."
+//
+//	dnsname.Equal("www.example.com", "WWW.example.com.")
+//	// true
+//
+// Labels may contain escaped characters, as defined in RFC 1035, section
+// 5.1: either a backslash followed by a single character (like "\.") or by
+// three decimal digits (like "\046").
+package dnsname
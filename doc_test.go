@@ -1,7 +1,10 @@
 package pdns
 
 import (
+	"log/slog"
 	"os"
+
+	"github.com/mittwald/go-powerdns/pdnshttp"
 )
 
 // This example uses with WithAPIKeyAuthentication function to add API-key based authentication
@@ -34,3 +37,41 @@ func ExampleNew_withDebugging() {
 
 	client.Status()
 }
+
+// This example uses the WithRetryPolicy function to automatically retry failed
+// idempotent requests; for example, while the PowerDNS server is restarting.
+func ExampleNew_withRetryPolicy() {
+	policy := pdnshttp.DefaultRetryPolicy()
+	policy.MaxAttempts = 5
+
+	client, err := New(
+		WithBaseURL("http://your-dns-server.example:8081"),
+		WithAPIKeyAuthentication("super-secret"),
+		WithRetryPolicy(policy),
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	client.Status()
+}
+
+// This example uses the WithLogger function to emit one structured log record
+// for each API request. With the debug level enabled, request and response
+// bodies will be logged, too (with secrets redacted).
+func ExampleNew_withLogger() {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := New(
+		WithBaseURL("http://your-dns-server.example:8081"),
+		WithAPIKeyAuthentication("super-secret"),
+		WithLogger(logger),
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	client.Status()
+}
@@ -1,9 +1,10 @@
 module github.com/mittwald/go-powerdns
 
-go 1.22
+go 1.23
 
 require (
 	github.com/stretchr/testify v1.3.0
+	golang.org/x/net v0.34.0
 	gopkg.in/h2non/gock.v1 v1.0.14
 )
 
@@ -11,4 +12,5 @@ require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/h2non/parth v0.0.0-20190131123155-b4df798d6542 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
 )
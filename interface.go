@@ -3,6 +3,7 @@ package pdns
 import (
 	"context"
 
+	"github.com/mittwald/go-powerdns/apis/autoprimaries"
 	"github.com/mittwald/go-powerdns/apis/cryptokeys"
 	"github.com/mittwald/go-powerdns/apis/metadata"
 	"github.com/mittwald/go-powerdns/apis/networks"
@@ -12,6 +13,7 @@ import (
 	"github.com/mittwald/go-powerdns/apis/cache"
 	"github.com/mittwald/go-powerdns/apis/search"
 	"github.com/mittwald/go-powerdns/apis/servers"
+	"github.com/mittwald/go-powerdns/apis/statistics"
 	"github.com/mittwald/go-powerdns/apis/zones"
 )
 
@@ -54,4 +56,10 @@ type Client interface {
 
 	// TsigKeys returns a specialized API for TSIG keys
 	TsigKeys() tsigkey.Client
+
+	// Statistics returns a specialized API for server statistics
+	Statistics() statistics.Client
+
+	// Autoprimaries returns a specialized API for autoprimaries
+	Autoprimaries() autoprimaries.Client
 }
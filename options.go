@@ -6,6 +6,7 @@ import (
 	"github.com/mittwald/go-powerdns/pdnshttp"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 )
 
@@ -82,9 +83,74 @@ func WithTLSAuthentication(caFile string, clientCertFile string, clientKeyFile s
 
 // WithDebuggingOutput can be used to supply an io.Writer to the client into which all
 // outgoing HTTP requests and their responses will be logged. Useful for debugging.
+//
+// Note that the output is not redacted, and will contain your API key and any secrets
+// contained in request and response bodies; consider using WithLogger instead.
 func WithDebuggingOutput(out io.Writer) ClientOption {
 	return func(c *client) error {
 		c.debugOutput = out
 		return nil
 	}
 }
+
+// WithRetryPolicy configures the client to automatically retry failed requests
+// (transport errors and certain 5xx responses) with exponential backoff. Only
+// idempotent requests are retried; by default, this excludes POST and PATCH
+// requests (see pdnshttp.RetryPolicy for how to override this). Use
+// pdnshttp.DefaultRetryPolicy() as a starting point for your own policy.
+func WithRetryPolicy(policy pdnshttp.RetryPolicy) ClientOption {
+	return func(c *client) error {
+		c.httpOptions = append(c.httpOptions, pdnshttp.WithRetryPolicy(policy))
+		return nil
+	}
+}
+
+// WithRateLimit limits the rate at which the client sends requests to the
+// PowerDNS API, and the number of requests that are executed concurrently
+// (see pdnshttp.RateLimit). Requests exceeding the limit will block until they
+// may be sent, or until their context is cancelled.
+func WithRateLimit(limit pdnshttp.RateLimit) ClientOption {
+	return func(c *client) error {
+		c.httpOptions = append(c.httpOptions, pdnshttp.WithRateLimit(limit))
+		return nil
+	}
+}
+
+// WithReadWriteRateLimits works like WithRateLimit, but applies separate limits
+// to reading (GET) and writing (POST, PUT, PATCH, DELETE) requests.
+func WithReadWriteRateLimits(read, write pdnshttp.RateLimit) ClientOption {
+	return func(c *client) error {
+		c.httpOptions = append(c.httpOptions, pdnshttp.WithReadWriteRateLimits(read, write))
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of API responses to the given number of
+// bytes; larger responses fail with pdnshttp.ErrResponseTooLarge. This protects
+// against running out of memory, e.g. when retrieving very large zones or when
+// a misbehaving proxy sends an endless response.
+func WithMaxResponseSize(n int64) ClientOption {
+	return func(c *client) error {
+		c.httpOptions = append(c.httpOptions, pdnshttp.WithMaxResponseSize(n))
+		return nil
+	}
+}
+
+// WithMiddleware adds middlewares that wrap every request sent to the PowerDNS
+// API; they can inspect (and modify) each request, its response and error, and
+// measure its duration. Middlewares are applied in order; the first middleware
+// is the outermost one. See pdnshttp.Observer for a simple way to build one.
+func WithMiddleware(mw ...pdnshttp.Middleware) ClientOption {
+	return func(c *client) error {
+		c.httpOptions = append(c.httpOptions, pdnshttp.WithMiddleware(mw...))
+		return nil
+	}
+}
+
+// WithLogger configures the client to emit one structured log record per API request
+// (see pdnshttp.NewLoggingMiddleware). Request and response bodies are only logged when
+// the logger has the debug level enabled; the API key and secrets contained in bodies
+// (like TSIG keys and cryptokey private keys) are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return WithMiddleware(pdnshttp.NewLoggingMiddleware(logger, pdnshttp.LoggingConfig{}))
+}
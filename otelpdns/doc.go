@@ -0,0 +1,11 @@
+// Package otelpdns provides OpenTelemetry instrumentation for the PowerDNS client.
+//
+// It is a separate Go module, so that the client library itself does not depend on
+// OpenTelemetry. The instrumentation is added to a client using WithInstrumentation:
+//
+//	client, err := pdns.New(
+//	    pdns.WithBaseURL("http://localhost:8081"),
+//	    pdns.WithAPIKeyAuthentication("secret"),
+//	    otelpdns.WithInstrumentation(),
+//	)
+package otelpdns
@@ -0,0 +1,153 @@
+package otelpdns
+
+import (
+	"net/http"
+	"time"
+
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name used for tracers and meters.
+const ScopeName = "github.com/mittwald/go-powerdns/otelpdns"
+
+// Attribute keys used on spans and metrics, in addition to the standard HTTP
+// semantic convention attributes.
+const (
+	AttrOperation = attribute.Key("pdns.operation")
+	AttrServerID  = attribute.Key("pdns.server_id")
+	AttrZoneID    = attribute.Key("pdns.zone_id")
+
+	attrMethod     = attribute.Key("http.request.method")
+	attrStatusCode = attribute.Key("http.response.status_code")
+	attrRoute      = attribute.Key("http.route")
+	attrURLFull    = attribute.Key("url.full")
+	attrServerAddr = attribute.Key("server.address")
+	attrErrorType  = attribute.Key("error.type")
+)
+
+// WithInstrumentation returns a client option that adds tracing and metrics
+// to a PowerDNS client.
+func WithInstrumentation(opts ...Option) pdns.ClientOption {
+	return pdns.WithMiddleware(NewMiddleware(opts...))
+}
+
+// NewMiddleware returns a middleware that starts a client span for each API
+// request and records its duration and errors. The span is started as a
+// child of the span contained in the request's context, and the trace context
+// is propagated to the PowerDNS API (or any proxy in front of it).
+//
+// Spans and metrics are named after the API operation; for example
+// "GET /servers/{server_id}/zones/{zone_id}".
+func NewMiddleware(opts ...Option) pdnshttp.Middleware {
+	cfg := newConfig(opts)
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	duration, err := meter.Float64Histogram(
+		"pdns.client.request.duration",
+		metric.WithDescription("Duration of PowerDNS API requests"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+		duration = noop.Float64Histogram{}
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"pdns.client.request.errors",
+		metric.WithDescription("Number of failed PowerDNS API requests"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+		errorCount = noop.Int64Counter{}
+	}
+
+	return pdnshttp.MiddlewareFunc(func(next pdnshttp.DoFunc) pdnshttp.DoFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			r := parseRoute(req.URL.Path)
+			operation := req.Method + " " + r.Template
+
+			attrs := []attribute.KeyValue{
+				AttrOperation.String(operation),
+				attrMethod.String(req.Method),
+				attrRoute.String(r.Template),
+			}
+
+			if id, ok := r.Params["server_id"]; ok {
+				attrs = append(attrs, AttrServerID.String(id))
+			}
+
+			if id, ok := r.Params["zone_id"]; ok {
+				attrs = append(attrs, AttrZoneID.String(id))
+			}
+
+			ctx, span := tracer.Start(req.Context(), operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(attrURLFull.String(req.URL.String()), attrServerAddr.String(req.URL.Hostname())),
+			)
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			res, err := next(req)
+			elapsed := time.Since(start)
+
+			metricAttrs := []attribute.KeyValue{AttrOperation.String(operation)}
+
+			if res != nil {
+				span.SetAttributes(attrStatusCode.Int(res.StatusCode))
+				metricAttrs = append(metricAttrs, attrStatusCode.Int(res.StatusCode))
+			}
+
+			if err != nil {
+				errType := errorType(err)
+
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				span.SetAttributes(attrErrorType.String(errType))
+
+				metricAttrs = append(metricAttrs, attrErrorType.String(errType))
+				errorCount.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+			}
+
+			duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(metricAttrs...))
+
+			return res, err
+		}
+	})
+}
+
+// errorType returns a low-cardinality description of an error.
+func errorType(err error) string {
+	switch {
+	case pdnshttp.IsNotFound(err):
+		return "not_found"
+	case pdnshttp.IsUnauthorized(err):
+		return "unauthorized"
+	case pdnshttp.IsForbidden(err):
+		return "forbidden"
+	case pdnshttp.IsConflict(err):
+		return "conflict"
+	case pdnshttp.IsUnprocessable(err):
+		return "unprocessable"
+	case pdnshttp.IsServerError(err):
+		return "server_error"
+	case pdnshttp.StatusCode(err) != 0:
+		return "unexpected_status"
+	}
+
+	return "transport"
+}
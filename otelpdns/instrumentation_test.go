@@ -0,0 +1,141 @@
+package otelpdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func buildInstrumentedClient(t *testing.T, handler http.HandlerFunc) (pdns.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	c, err := pdns.New(
+		pdns.WithBaseURL(srv.URL),
+		pdns.WithHTTPClient(srv.Client()),
+		WithInstrumentation(
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+			WithPropagators(propagation.TraceContext{}),
+		),
+	)
+	require.NoError(t, err)
+
+	return c, spans, reader
+}
+
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestInstrumentationCreatesSpanPerRequest(t *testing.T) {
+	var traceparent string
+
+	c, spans, reader := buildInstrumentedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "example.com.", "name": "example.com.", "kind": "Native", "type": "Zone"}`))
+	})
+
+	_, err := c.Zones().GetZone(context.Background(), "localhost", "example.com.")
+	require.NoError(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+
+	span := ended[0]
+	assert.Equal(t, "GET /servers/{server_id}/zones/{zone_id}", span.Name())
+	assert.NotEmpty(t, traceparent, "trace context should be propagated")
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+
+	v, ok := attributeValue(span.Attributes(), AttrServerID)
+	assert.True(t, ok)
+	assert.Equal(t, "localhost", v.AsString())
+
+	v, ok = attributeValue(span.Attributes(), AttrZoneID)
+	assert.True(t, ok)
+	assert.Equal(t, "example.com.", v.AsString())
+
+	v, ok = attributeValue(span.Attributes(), attrStatusCode)
+	assert.True(t, ok)
+	assert.Equal(t, int64(http.StatusOK), v.AsInt64())
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	names := make([]string, 0)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+
+	assert.Contains(t, names, "pdns.client.request.duration")
+	assert.NotContains(t, names, "pdns.client.request.errors", "no errors should have been recorded")
+}
+
+func TestInstrumentationRecordsErrors(t *testing.T) {
+	c, spans, reader := buildInstrumentedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error": "Conflict"}`))
+	})
+
+	_, err := c.Zones().CreateZone(context.Background(), "localhost", zonesFixture())
+	require.Error(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	assert.Equal(t, "POST /servers/{server_id}/zones", ended[0].Name())
+	assert.Equal(t, codes.Error, ended[0].Status().Code)
+
+	v, ok := attributeValue(ended[0].Attributes(), attrErrorType)
+	assert.True(t, ok)
+	assert.Equal(t, "conflict", v.AsString())
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var errorsMetric *metricdata.Metrics
+	for i := range rm.ScopeMetrics[0].Metrics {
+		if rm.ScopeMetrics[0].Metrics[i].Name == "pdns.client.request.errors" {
+			errorsMetric = &rm.ScopeMetrics[0].Metrics[i]
+		}
+	}
+
+	require.NotNil(t, errorsMetric)
+	sum, ok := errorsMetric.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+}
+
+func zonesFixture() zones.Zone {
+	return zones.Zone{
+		Name:        "example.com.",
+		Kind:        zones.ZoneKindNative,
+		Nameservers: zones.ZoneNameservers{"ns1.example.com."},
+	}
+}
@@ -0,0 +1,60 @@
+package otelpdns
+
+import "strings"
+
+// identifiers maps the collection segments of the PowerDNS API to the names
+// of the identifiers that follow them in a request path.
+var identifiers = map[string][]string{
+	"servers":       {"server_id"},
+	"zones":         {"zone_id"},
+	"cryptokeys":    {"cryptokey_id"},
+	"metadata":      {"metadata_kind"},
+	"tsigkeys":      {"tsigkey_id"},
+	"config":        {"config_setting_name"},
+	"autoprimaries": {"ip", "nameserver"},
+	"views":         {"view", "zone_variant"},
+	"networks":      {"ip", "prefixlen"},
+}
+
+// route describes a parsed request path.
+type route struct {
+	// Template is the request path with all identifiers replaced by
+	// placeholders, e.g. "/servers/{server_id}/zones/{zone_id}/export".
+	Template string
+
+	// Params contains the values of all identifiers contained in the path.
+	Params map[string]string
+}
+
+// parseRoute parses a PowerDNS API request path; any path prefix preceding
+// the "servers" segment (like "/api/v1") is ignored.
+func parseRoute(path string) route {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := range segments {
+		if segments[i] == "servers" {
+			segments = segments[i:]
+			break
+		}
+	}
+
+	r := route{Params: map[string]string{}}
+	out := make([]string, 0, len(segments))
+
+	for i := 0; i < len(segments); i++ {
+		out = append(out, segments[i])
+
+		for _, name := range identifiers[segments[i]] {
+			if i+1 >= len(segments) {
+				break
+			}
+
+			i++
+			r.Params[name] = segments[i]
+			out = append(out, "{"+name+"}")
+		}
+	}
+
+	r.Template = "/" + strings.Join(out, "/")
+	return r
+}
@@ -0,0 +1,32 @@
+package otelpdns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoute(t *testing.T) {
+	cases := []struct {
+		path     string
+		template string
+		params   map[string]string
+	}{
+		{"/api/v1/servers", "/servers", map[string]string{}},
+		{"/api/v1/servers/localhost", "/servers/{server_id}", map[string]string{"server_id": "localhost"}},
+		{"/api/v1/servers/localhost/zones/example.com./export", "/servers/{server_id}/zones/{zone_id}/export", map[string]string{"server_id": "localhost", "zone_id": "example.com."}},
+		{"/api/v1/servers/localhost/zones/example.com./cryptokeys/12", "/servers/{server_id}/zones/{zone_id}/cryptokeys/{cryptokey_id}", map[string]string{"server_id": "localhost", "zone_id": "example.com.", "cryptokey_id": "12"}},
+		{"/api/v1/servers/localhost/cache/flush", "/servers/{server_id}/cache/flush", map[string]string{"server_id": "localhost"}},
+		{"/api/v1/servers/localhost/networks/192.0.2.0/24", "/servers/{server_id}/networks/{ip}/{prefixlen}", map[string]string{"server_id": "localhost", "ip": "192.0.2.0", "prefixlen": "24"}},
+		{"/custom/prefix/servers/localhost/statistics", "/servers/{server_id}/statistics", map[string]string{"server_id": "localhost"}},
+	}
+
+	for i := range cases {
+		t.Run(cases[i].path, func(t *testing.T) {
+			r := parseRoute(cases[i].path)
+
+			assert.Equal(t, cases[i].template, r.Template)
+			assert.Equal(t, cases[i].params, r.Params)
+		})
+	}
+}
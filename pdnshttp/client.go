@@ -1,8 +1,10 @@
 package pdnshttp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -16,10 +18,18 @@ type Client struct {
 	httpClient    *http.Client
 	authenticator ClientAuthenticator
 	debugOutput   io.Writer
+	retryPolicy   *RetryPolicy
+	readLimiter   *limiter
+	writeLimiter  *limiter
+	middlewares   []Middleware
+	do            DoFunc
+
+	maxResponseSize int64
 }
 
-// NewClient returns a new PowerDNS HTTP client
-func NewClient(baseURL string, hc *http.Client, auth ClientAuthenticator, debugOutput io.Writer) *Client {
+// NewClient returns a new PowerDNS HTTP client. Optional behaviour (like retries)
+// can be configured using additional ClientOptions.
+func NewClient(baseURL string, hc *http.Client, auth ClientAuthenticator, debugOutput io.Writer, opts ...ClientOption) *Client {
 	u, err := url.ParseRequestURI(baseURL)
 	if err != nil {
 		panic(err)
@@ -36,6 +46,15 @@ func NewClient(baseURL string, hc *http.Client, auth ClientAuthenticator, debugO
 		debugOutput:   debugOutput,
 	}
 
+	for i := range opts {
+		opts[i](&c)
+	}
+
+	c.do = c.execute
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		c.do = c.middlewares[i].Wrap(c.do)
+	}
+
 	return &c
 }
 
@@ -83,64 +102,233 @@ func (c *Client) Delete(ctx context.Context, path string, out interface{}, opts
 }
 
 func (c *Client) Do(ctx context.Context, req *http.Request, out interface{}) error {
+	return c.stream(ctx, req, true, func(res *http.Response) error {
+		if out == nil || res.StatusCode == http.StatusNoContent {
+			return nil
+		}
+
+		if w, ok := out.(io.Writer); ok {
+			_, err := io.Copy(w, res.Body)
+			return err
+		}
+
+		return json.NewDecoder(res.Body).Decode(out)
+	})
+}
+
+// DoStream executes a request like Do, but passes the (successful) response
+// to fn instead of decoding it. fn may read the response body incrementally,
+// to process large responses without buffering them; the body is closed
+// after fn returns.
+//
+// While fn runs, the request holds a slot for streamed responses instead of
+// a regular rate limiter slot (see RateLimit.MaxInFlight), so fn may issue
+// further requests with the same client without deadlocking. Middlewares
+// only observe the request up to the response headers; the time that fn
+// spends reading the body is not part of their measurements.
+func (c *Client) DoStream(ctx context.Context, req *http.Request, fn func(res *http.Response) error) error {
+	return c.stream(ctx, req, false, fn)
+}
+
+// stream executes a request and passes the successful response to fn. If
+// holdLimiter is true, the regular rate limiter slot is held while fn runs;
+// otherwise, a slot for streamed responses is held for the whole request, and
+// the regular slot is released as soon as the response headers were received.
+func (c *Client) stream(ctx context.Context, req *http.Request, holdLimiter bool, fn func(res *http.Response) error) error {
 	req = req.WithContext(ctx)
+	lim := c.limiter(req)
+
+	// the stream slot is taken first, so that requests waiting for one do not
+	// block regular requests
+	if !holdLimiter {
+		releaseStream, err := lim.acquireStream(ctx)
+		if err != nil {
+			return err
+		}
 
-	reqDump, _ := httputil.DumpRequestOut(req, true)
-	c.debugOutput.Write(reqDump)
+		defer releaseStream()
+	}
 
-	res, err := c.httpClient.Do(req)
+	release, err := lim.acquire(ctx)
 	if err != nil {
 		return err
 	}
 
-	resDump, _ := httputil.DumpResponse(res, true)
-	c.debugOutput.Write(resDump)
+	if holdLimiter {
+		defer release()
+	}
+
+	res, err := c.do(req)
+	if !holdLimiter {
+		release()
+	}
+
+	if res != nil {
+		defer res.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if res == nil {
+		return fmt.Errorf("no response received for %s %s", req.Method, req.URL)
+	}
+
+	return fn(res)
+}
+
+// limitResponseBody enforces the client's maximum response size: responses
+// that announce a larger body are rejected right away, and the bodies of all
+// others fail to read once they exceed the limit.
+func (c *Client) limitResponseBody(req *http.Request, res *http.Response) error {
+	tooLarge := ErrResponseTooLarge{URL: req.URL.String(), Limit: c.maxResponseSize}
+	if res.ContentLength > c.maxResponseSize {
+		_ = res.Body.Close()
+		return tooLarge
+	}
+
+	res.Body = &limitedBody{ReadCloser: res.Body, remaining: c.maxResponseSize, err: tooLarge}
+	return nil
+}
+
+// limitedBody fails reading a response body once more than a certain number
+// of bytes were read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, b.err
+	}
+
+	// read one byte more than allowed, to detect bodies that are too large
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+
+	if b.remaining < 0 {
+		return n + int(b.remaining), b.err
+	}
+
+	return n, err
+}
+
+// execute is the innermost DoFunc of the middleware chain; it executes a
+// request and converts error responses into errors. For error responses, the
+// response is returned alongside the error, with its body buffered so that it
+// can still be read by middlewares.
+func (c *Client) execute(req *http.Request) (*http.Response, error) {
+	res, err := c.roundTrip(req.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 400 {
+		return res, nil
+	}
+
+	var reader io.Reader = res.Body
+	if c.maxResponseSize > 0 {
+		reader = io.LimitReader(res.Body, c.maxResponseSize)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	_ = res.Body.Close()
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return res, err
+	}
 
 	if res.StatusCode == http.StatusNotFound {
-		return ErrNotFound{URL: req.URL.String()}
-	} else if res.StatusCode >= 400 {
-		if res.Header.Get("Content-Type") == "application/json" {
-			// Get a human readable error message
-			// from PowerDNS API response
-			var er ErrResponse
-
-			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
-				return err
-			}
+		return res, ErrNotFound{URL: req.URL.String()}
+	}
 
-			return ErrUnexpectedStatus{
-				URL:         req.URL.String(),
-				StatusCode:  res.StatusCode,
-				ErrResponse: er,
+	er := ErrResponse{Message: string(body)}
+
+	if res.Header.Get("Content-Type") == "application/json" {
+		// Get a human readable error message
+		// from PowerDNS API response
+		er = ErrResponse{}
+
+		if err := json.Unmarshal(body, &er); err != nil {
+			return res, err
+		}
+	}
+
+	return res, newStatusError(ErrUnexpectedStatus{
+		URL:         req.URL.String(),
+		StatusCode:  res.StatusCode,
+		ErrResponse: er,
+	})
+}
+
+// roundTrip executes a request, retrying it according to the client's retry
+// policy (if any).
+func (c *Client) roundTrip(ctx context.Context, req *http.Request) (*http.Response, error) {
+	// dumping responses buffers their bodies, so only do it when needed
+	debug := c.debugOutput != nil && c.debugOutput != io.Discard
+
+	for attempt := 1; ; attempt++ {
+		if debug {
+			reqDump, _ := httputil.DumpRequestOut(req, true)
+			c.debugOutput.Write(reqDump)
+		}
+
+		res, err := c.httpClient.Do(req)
+
+		// limit the body before anything (like the debug output or the
+		// middlewares) reads it
+		if err == nil && c.maxResponseSize > 0 {
+			if err := c.limitResponseBody(req, res); err != nil {
+				return nil, err
 			}
 		}
 
-		body, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return err
+		if err == nil && debug {
+			resDump, _ := httputil.DumpResponse(res, true)
+			c.debugOutput.Write(resDump)
 		}
 
-		return ErrUnexpectedStatus{
-			URL:        req.URL.String(),
-			StatusCode: res.StatusCode,
-			ErrResponse: ErrResponse{
-				Message: string(body),
-			},
+		if c.retryPolicy == nil || !c.retryPolicy.shouldRetry(req, attempt, res, err) {
+			return res, err
 		}
-	}
 
-	if out != nil {
-		if w, ok := out.(io.Writer); ok {
-			_, err := io.Copy(w, res.Body)
-			return err
+		wait := c.retryPolicy.backoff(attempt, res)
+		if res != nil {
+			discardBody(res)
 		}
 
-		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
-			return err
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+
+		// each retry counts against the request rate, so that retries do
+		// not add to the load of a server that is already in trouble
+		if err := c.limiter(req).wait(ctx); err != nil {
+			return nil, err
 		}
 	}
+}
 
-	return nil
+// limiter returns the rate limiter that applies to a request.
+func (c *Client) limiter(req *http.Request) *limiter {
+	if isReadMethod(req.Method) {
+		return c.readLimiter
+	}
+
+	return c.writeLimiter
 }
 
 func (c *Client) doRequest(ctx context.Context, method string, path string, out interface{}, opts ...RequestOption) error {
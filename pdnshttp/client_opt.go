@@ -0,0 +1,53 @@
+package pdnshttp
+
+// ClientOption is a special type of function that can be passed to NewClient;
+// it is used to configure optional behaviour of the HTTP client.
+type ClientOption func(*Client)
+
+// WithRetryPolicy configures the client to retry failed requests according to
+// the given policy. Without this option, each request is attempted only once.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &p
+	}
+}
+
+// WithRateLimit limits the rate and concurrency of all requests sent by the
+// client. Requests that exceed the limit block until they may be sent, or
+// until their context is cancelled.
+func WithRateLimit(l RateLimit) ClientOption {
+	return func(c *Client) {
+		lim := newLimiter(l)
+		c.readLimiter = lim
+		c.writeLimiter = lim
+	}
+}
+
+// WithReadWriteRateLimits works like WithRateLimit, but uses separate limits
+// for reading (GET, HEAD, OPTIONS) and writing (all other) requests.
+func WithReadWriteRateLimits(read, write RateLimit) ClientOption {
+	return func(c *Client) {
+		c.readLimiter = newLimiter(read)
+		c.writeLimiter = newLimiter(write)
+	}
+}
+
+// WithMaxResponseSize limits the size of response bodies to the given number
+// of bytes. Reading a larger response fails with ErrResponseTooLarge, instead
+// of consuming an unbounded amount of memory. The limit also applies to the
+// debug output and to middlewares that read the response body. A limit of 0
+// (the default) disables the check.
+func WithMaxResponseSize(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxResponseSize = n
+	}
+}
+
+// WithMiddleware adds middlewares that wrap each request executed by the
+// client. Middlewares are applied in order; the first middleware is the
+// outermost one.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
@@ -1,11 +1,15 @@
 package pdnshttp
 
 import (
+	"bytes"
 	"context"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/h2non/gock.v1"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -42,3 +46,118 @@ func TestBaseURLAlreadyContainsPath(t *testing.T) {
 	require.Nil(t, err)
 	require.True(t, gock.IsDone(), "still has pending mocks")
 }
+
+func TestDoStreamPassesResponseBody(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://test.example").
+		Get("/api/v1/servers").
+		Reply(http.StatusOK).
+		BodyString(`streamed`)
+
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := NewClient("http://test.example", hc, &APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+
+	req, err := c.NewRequest(http.MethodGet, "/servers", nil)
+	require.Nil(t, err)
+
+	var body []byte
+
+	err = c.DoStream(context.Background(), req, func(res *http.Response) error {
+		body, err = io.ReadAll(res.Body)
+		return err
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "streamed", string(body))
+}
+
+func TestDoStreamDoesNotPassErrorResponses(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://test.example").
+		Get("/api/v1/servers").
+		Reply(http.StatusNotFound)
+
+	hc := &http.Client{Transport: gock.DefaultTransport}
+	c := NewClient("http://test.example", hc, &APIKeyAuthenticator{APIKey: "secret"}, io.Discard)
+
+	req, err := c.NewRequest(http.MethodGet, "/servers", nil)
+	require.Nil(t, err)
+
+	called := false
+	err = c.DoStream(context.Background(), req, func(*http.Response) error {
+		called = true
+		return nil
+	})
+
+	require.True(t, IsNotFound(err))
+	require.False(t, called)
+}
+
+func newMaxResponseSizeTestServer(t *testing.T, body string, chunked bool, debugOutput io.Writer, opts ...ClientOption) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if chunked {
+			// flushing before writing the body prevents a Content-Length header
+			w.(http.Flusher).Flush()
+		}
+
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	opts = append(opts, WithMaxResponseSize(16))
+	return NewClient(srv.URL, srv.Client(), &APIKeyAuthenticator{APIKey: "secret"}, debugOutput, opts...)
+}
+
+func TestMaxResponseSizeAllowsSmallResponses(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		c := newMaxResponseSizeTestServer(t, `{"foo": "bar"}`, chunked, io.Discard)
+
+		out := map[string]string{}
+		err := c.Get(context.Background(), "/servers", &out)
+
+		require.Nil(t, err)
+		require.Equal(t, "bar", out["foo"])
+	}
+}
+
+func TestMaxResponseSizeRejectsLargeResponses(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		c := newMaxResponseSizeTestServer(t, `{"foo": "`+strings.Repeat("x", 100)+`"}`, chunked, io.Discard)
+
+		out := map[string]string{}
+		err := c.Get(context.Background(), "/servers", &out)
+
+		require.NotNil(t, err)
+		require.True(t, IsResponseTooLarge(err), "chunked: %v, error: %v", chunked, err)
+	}
+}
+
+func TestMaxResponseSizeAppliesToStreamedResponses(t *testing.T) {
+	c := newMaxResponseSizeTestServer(t, strings.Repeat("x", 17), true, io.Discard)
+
+	out := strings.Builder{}
+	err := c.Get(context.Background(), "/servers", &out)
+
+	require.True(t, IsResponseTooLarge(err))
+	require.Equal(t, 16, out.Len())
+}
+
+func TestMaxResponseSizeAppliesBeforeDebugOutputAndMiddlewares(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	debugOutput := bytes.Buffer{}
+
+	for _, chunked := range []bool{false, true} {
+		c := newMaxResponseSizeTestServer(t, strings.Repeat("x", 100), chunked, &debugOutput, WithMiddleware(NewLoggingMiddleware(logger, LoggingConfig{})))
+
+		out := strings.Builder{}
+		err := c.Get(context.Background(), "/servers", &out)
+
+		require.True(t, IsResponseTooLarge(err), "chunked: %v, error: %v", chunked, err)
+		require.True(t, out.Len() <= 16)
+		require.NotContains(t, debugOutput.String(), strings.Repeat("x", 17))
+	}
+}
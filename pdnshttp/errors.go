@@ -1,6 +1,12 @@
 package pdnshttp
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
 
 type ErrNotFound struct {
 	URL string
@@ -10,6 +16,41 @@ func (e ErrNotFound) Error() string {
 	return fmt.Sprintf("not found: %s", e.URL)
 }
 
+// Is makes errors.Is match any ErrNotFound, regardless of its URL.
+func (e ErrNotFound) Is(target error) bool {
+	switch target.(type) {
+	case ErrNotFound, *ErrNotFound:
+		return true
+	}
+
+	return false
+}
+
+// ErrResponseTooLarge is returned when a response body exceeds the maximum
+// response size configured with WithMaxResponseSize.
+type ErrResponseTooLarge struct {
+	URL   string
+	Limit int64
+}
+
+func (e ErrResponseTooLarge) Error() string {
+	return fmt.Sprintf("response exceeds maximum size of %d bytes: %s", e.Limit, e.URL)
+}
+
+// Is makes errors.Is match any ErrResponseTooLarge, regardless of its fields.
+func (e ErrResponseTooLarge) Is(target error) bool {
+	switch target.(type) {
+	case ErrResponseTooLarge, *ErrResponseTooLarge:
+		return true
+	}
+
+	return false
+}
+
+// ErrUnexpectedStatus is an error response of the PowerDNS API. Responses
+// with status codes that have a more specific error type (see newStatusError)
+// are returned as that type, which wraps the ErrUnexpectedStatus; use
+// errors.As instead of a type assertion to access it.
 type ErrUnexpectedStatus struct {
 	URL        string
 	StatusCode int
@@ -29,12 +70,164 @@ type ErrResponse struct {
 	Messages []string `json:"errors,omitempty"`
 }
 
-func IsNotFound(err error) bool {
-	switch err.(type) {
-	case ErrNotFound:
+// ErrUnauthorized is returned when the PowerDNS API responds with status 401;
+// usually, this means that the API key is missing or wrong.
+type ErrUnauthorized struct{ ErrUnexpectedStatus }
+
+// ErrForbidden is returned when the PowerDNS API responds with status 403.
+type ErrForbidden struct{ ErrUnexpectedStatus }
+
+// ErrConflict is returned when the PowerDNS API responds with status 409;
+// for example, when creating a zone that already exists.
+type ErrConflict struct{ ErrUnexpectedStatus }
+
+// ErrUnprocessable is returned when the PowerDNS API responds with status 422;
+// this is the status used by PowerDNS for most validation errors. The message
+// returned by PowerDNS is available in the embedded ErrResponse.
+type ErrUnprocessable struct{ ErrUnexpectedStatus }
+
+// ErrServerError is returned when the PowerDNS API (or a proxy in front of it)
+// responds with a 5xx status code.
+type ErrServerError struct{ ErrUnexpectedStatus }
+
+// Unwrap makes errors.As match the embedded ErrUnexpectedStatus.
+func (e ErrUnauthorized) Unwrap() error  { return e.ErrUnexpectedStatus }
+func (e ErrForbidden) Unwrap() error     { return e.ErrUnexpectedStatus }
+func (e ErrConflict) Unwrap() error      { return e.ErrUnexpectedStatus }
+func (e ErrUnprocessable) Unwrap() error { return e.ErrUnexpectedStatus }
+func (e ErrServerError) Unwrap() error   { return e.ErrUnexpectedStatus }
+
+// Is makes errors.Is match any error of the same type, regardless of its
+// contents.
+func (e ErrUnauthorized) Is(target error) bool {
+	switch target.(type) {
+	case ErrUnauthorized, *ErrUnauthorized:
+		return true
+	}
+	return false
+}
+
+func (e ErrForbidden) Is(target error) bool {
+	switch target.(type) {
+	case ErrForbidden, *ErrForbidden:
+		return true
+	}
+	return false
+}
+
+func (e ErrConflict) Is(target error) bool {
+	switch target.(type) {
+	case ErrConflict, *ErrConflict:
+		return true
+	}
+	return false
+}
+
+func (e ErrUnprocessable) Is(target error) bool {
+	switch target.(type) {
+	case ErrUnprocessable, *ErrUnprocessable:
 		return true
-	case *ErrNotFound:
+	}
+	return false
+}
+
+func (e ErrServerError) Is(target error) bool {
+	switch target.(type) {
+	case ErrServerError, *ErrServerError:
+		return true
+	}
+	return false
+}
+
+// newStatusError wraps an ErrUnexpectedStatus into a more specific error type,
+// depending on its status code. Note that, because of this, type assertions
+// like err.(ErrUnexpectedStatus) no longer match these responses.
+func newStatusError(e ErrUnexpectedStatus) error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized{e}
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden{e}
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict{e}
+	case e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrUnprocessable{e}
+	case e.StatusCode >= 500:
+		return ErrServerError{e}
+	}
+
+	return e
+}
+
+// IsNotFound returns true if the error (or any error it wraps) is an ErrNotFound.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound{})
+}
+
+// IsResponseTooLarge returns true if the error (or any error it wraps) is an ErrResponseTooLarge.
+func IsResponseTooLarge(err error) bool {
+	return errors.Is(err, ErrResponseTooLarge{})
+}
+
+// IsUnauthorized returns true if the error (or any error it wraps) is an ErrUnauthorized.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized{})
+}
+
+// IsForbidden returns true if the error (or any error it wraps) is an ErrForbidden.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden{})
+}
+
+// IsConflict returns true if the error (or any error it wraps) is an ErrConflict.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict{})
+}
+
+// IsUnprocessable returns true if the error (or any error it wraps) is an ErrUnprocessable.
+func IsUnprocessable(err error) bool {
+	return errors.Is(err, ErrUnprocessable{})
+}
+
+// IsServerError returns true if the error (or any error it wraps) is an ErrServerError.
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServerError{})
+}
+
+// StatusCode returns the HTTP status code contained in an error returned by
+// this package, or 0 if the error does not contain a status code.
+func StatusCode(err error) int {
+	var us ErrUnexpectedStatus
+	if errors.As(err, &us) {
+		return us.StatusCode
+	}
+
+	if IsNotFound(err) {
+		return http.StatusNotFound
+	}
+
+	return 0
+}
+
+// IsRetryable returns true if the request that caused the error might succeed
+// when it is retried unchanged; this is the case for network errors, rate
+// limiting responses and the server errors in DefaultRetryableStatusCodes.
+// Cancelled contexts are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	switch StatusCode(err) {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
 		return true
+	case 0:
+		var netErr net.Error
+		return errors.As(err, &netErr)
 	}
 
 	return false
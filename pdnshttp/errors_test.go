@@ -0,0 +1,99 @@
+package pdnshttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorsAreClassifiedByStatusCode(t *testing.T) {
+	cases := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusUnauthorized, IsUnauthorized},
+		{http.StatusForbidden, IsForbidden},
+		{http.StatusConflict, IsConflict},
+		{http.StatusUnprocessableEntity, IsUnprocessable},
+		{http.StatusInternalServerError, IsServerError},
+		{http.StatusServiceUnavailable, IsServerError},
+	}
+
+	for i := range cases {
+		t.Run(fmt.Sprintf("status %d", cases[i].status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(cases[i].status)
+				_, _ = w.Write([]byte(`{"error": "something went wrong"}`))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, srv.Client(), nil, io.Discard)
+			err := c.Get(context.Background(), "/servers", nil)
+
+			require.Error(t, err)
+			assert.True(t, cases[i].check(err))
+			assert.True(t, cases[i].check(fmt.Errorf("wrapped: %w", err)), "wrapped errors should be classified")
+			assert.Equal(t, cases[i].status, StatusCode(err))
+		})
+	}
+}
+
+func TestUnprocessableErrorContainsMessage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error": "RRset example.com. IN A: Conflicts with pre-existing RRset"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard)
+	err := c.Patch(context.Background(), "/servers/localhost/zones/example.com.", nil)
+
+	var unprocessable ErrUnprocessable
+	require.True(t, errors.As(err, &unprocessable))
+	assert.Equal(t, "RRset example.com. IN A: Conflicts with pre-existing RRset", unprocessable.Message)
+
+	var unexpected ErrUnexpectedStatus
+	require.True(t, errors.As(err, &unexpected), "specific errors should unwrap to ErrUnexpectedStatus")
+	assert.Equal(t, http.StatusUnprocessableEntity, unexpected.StatusCode)
+
+	assert.False(t, IsConflict(err))
+	assert.False(t, IsNotFound(err))
+}
+
+func TestIsNotFoundMatchesPointers(t *testing.T) {
+	assert.True(t, IsNotFound(ErrNotFound{URL: "http://dns.example"}))
+	assert.True(t, IsNotFound(&ErrNotFound{URL: "http://dns.example"}))
+	assert.False(t, IsNotFound(errors.New("not found")))
+	assert.False(t, IsNotFound(nil))
+}
+
+func TestIsRetryable(t *testing.T) {
+	status := func(code int) error {
+		return newStatusError(ErrUnexpectedStatus{StatusCode: code})
+	}
+
+	assert.True(t, IsRetryable(status(http.StatusServiceUnavailable)))
+	assert.True(t, IsRetryable(status(http.StatusTooManyRequests)))
+	assert.True(t, IsRetryable(fmt.Errorf("wrapped: %w", status(http.StatusBadGateway))))
+	assert.True(t, IsRetryable(status(http.StatusInternalServerError)))
+	assert.False(t, IsRetryable(status(http.StatusNotImplemented)))
+	assert.False(t, IsRetryable(status(http.StatusConflict)))
+	assert.False(t, IsRetryable(ErrNotFound{}))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(nil))
+
+	c := NewClient("http://127.0.0.1:1", http.DefaultClient, nil, io.Discard)
+	err := c.Get(context.Background(), "/servers", nil)
+	require.Error(t, err)
+	assert.True(t, IsRetryable(err), "connection errors should be retryable")
+}
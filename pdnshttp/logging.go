@@ -0,0 +1,241 @@
+package pdnshttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRedactedFields contains the JSON fields whose values are redacted from
+// logged request and response bodies by default; these are the TSIG key secret
+// ("TSIGKey.Key") and the private key of a cryptokey ("Cryptokey.PrivateKey").
+var DefaultRedactedFields = []string{"key", "privatekey"}
+
+// redactedHeaders contains the headers whose values are never logged.
+var redactedHeaders = []string{"X-API-Key", "Authorization"}
+
+const redacted = "REDACTED"
+
+// LoggingConfig configures the middleware returned by NewLoggingMiddleware.
+type LoggingConfig struct {
+	// RedactedFields contains the names of JSON fields (compared
+	// case-insensitively) whose values are replaced in logged bodies. When
+	// nil, DefaultRedactedFields is used.
+	RedactedFields []string
+
+	// DisableRedaction causes bodies to be logged without any redaction. Only
+	// use this for local debugging.
+	DisableRedaction bool
+}
+
+// NewLoggingMiddleware returns a middleware that emits one structured log
+// record for each API request, containing the request method and path, the
+// response status, the request duration and (if any) the error message
+// returned by PowerDNS.
+//
+// If the logger has the debug level enabled, the record will additionally
+// contain the request headers and the request and response bodies; secrets
+// (like the API key and TSIG/cryptokey secrets) are redacted from these. Of
+// large response bodies, only a prefix is read for logging, so that streamed
+// responses are not buffered in memory.
+func NewLoggingMiddleware(logger *slog.Logger, cfg LoggingConfig) Middleware {
+	fields := cfg.RedactedFields
+	if fields == nil {
+		fields = DefaultRedactedFields
+	}
+
+	redact := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		redact[strings.ToLower(f)] = struct{}{}
+	}
+
+	return MiddlewareFunc(func(next DoFunc) DoFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			debug := logger.Enabled(ctx, slog.LevelDebug)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+			}
+
+			if debug {
+				attrs = append(attrs, slog.Any("request_headers", redactHeaders(req.Header)))
+
+				if body := requestBody(req); body != nil {
+					attrs = append(attrs, slog.String("request_body", string(redactBody(body, redact, cfg.DisableRedaction))))
+				}
+			}
+
+			start := time.Now()
+			res, err := next(req)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+			if res != nil {
+				attrs = append(attrs, slog.Int("status", res.StatusCode))
+
+				if debug {
+					attrs = append(attrs, responseBodyAttrs(res, redact, cfg.DisableRedaction)...)
+				}
+			}
+
+			level := slog.LevelInfo
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+
+				var us ErrUnexpectedStatus
+				if errors.As(err, &us) && us.Message != "" {
+					attrs = append(attrs, slog.String("pdns_error", us.Message))
+				}
+
+				level = slog.LevelError
+				if res != nil && res.StatusCode < 500 {
+					level = slog.LevelWarn
+				}
+			}
+
+			logger.LogAttrs(ctx, level, "PowerDNS API request", attrs...)
+
+			return res, err
+		}
+	})
+}
+
+// maxLoggedBodySize is the maximum number of bytes of a response body that are
+// logged; reading (and buffering) more would defeat streaming large responses.
+const maxLoggedBodySize = 16 * 1024
+
+// responseBodyAttrs reads (at most maxLoggedBodySize bytes of) a response
+// body for logging, and restores the body so that it can be read again.
+// Bodies that are truncated are only logged with redaction disabled, since
+// incomplete JSON cannot be redacted.
+func responseBodyAttrs(res *http.Response, redact map[string]struct{}, disableRedaction bool) []slog.Attr {
+	prefix, readErr := io.ReadAll(io.LimitReader(res.Body, maxLoggedBodySize+1))
+
+	// pass read errors (like ErrResponseTooLarge) on to whoever reads the body
+	// next
+	var rest io.Reader = res.Body
+	if readErr != nil {
+		rest = errorReader{readErr}
+	}
+
+	res.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(prefix), rest), Closer: res.Body}
+
+	if readErr != nil {
+		return nil
+	}
+
+	if len(prefix) <= maxLoggedBodySize {
+		return []slog.Attr{slog.String("response_body", string(redactBody(prefix, redact, disableRedaction)))}
+	}
+
+	attrs := []slog.Attr{slog.Bool("response_body_truncated", true)}
+	if disableRedaction {
+		attrs = append(attrs, slog.String("response_body", string(prefix[:maxLoggedBodySize])))
+	}
+
+	return attrs
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errorReader is a reader that always fails with err.
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// requestBody returns a copy of the request body without consuming it, or
+// nil if that is not possible.
+func requestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return nil
+	}
+
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+
+	defer rc.Close()
+
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		return nil
+	}
+
+	return body
+}
+
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+
+	for _, name := range redactedHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+
+	return out
+}
+
+// redactBody replaces the values of all given fields in a JSON body. Bodies
+// that are not valid JSON are returned unchanged.
+func redactBody(body []byte, fields map[string]struct{}, disabled bool) []byte {
+	if disabled || len(fields) == 0 {
+		return body
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+
+	if !redactValue(v, fields) {
+		return body
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+
+	return out
+}
+
+// redactValue redacts a decoded JSON value in place, and returns true if
+// anything was redacted.
+func redactValue(v interface{}, fields map[string]struct{}) bool {
+	changed := false
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k := range t {
+			if _, ok := fields[strings.ToLower(k)]; ok {
+				t[k] = redacted
+				changed = true
+				continue
+			}
+
+			changed = redactValue(t[k], fields) || changed
+		}
+	case []interface{}:
+		for i := range t {
+			changed = redactValue(t[i], fields) || changed
+		}
+	}
+
+	return changed
+}
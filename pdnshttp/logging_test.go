@@ -0,0 +1,144 @@
+package pdnshttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0)
+	dec := json.NewDecoder(buf)
+
+	for dec.More() {
+		rec := map[string]interface{}{}
+		require.NoError(t, dec.Decode(&rec))
+		out = append(out, rec)
+	}
+
+	return out
+}
+
+func TestLoggingMiddlewareEmitsOneRecordPerRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error": "Domain name is invalid"}`))
+	}))
+	defer srv.Close()
+
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	c := NewClient(srv.URL, srv.Client(), &APIKeyAuthenticator{APIKey: "secret"}, io.Discard,
+		WithMiddleware(NewLoggingMiddleware(logger, LoggingConfig{})))
+
+	err := c.Post(context.Background(), "/servers/localhost/zones", nil, WithJSONRequestBody(map[string]string{"name": "in valid"}))
+	require.Error(t, err)
+
+	records := decodeLogRecords(t, &buf)
+	require.Len(t, records, 1)
+
+	rec := records[0]
+	assert.Equal(t, "WARN", rec["level"])
+	assert.Equal(t, "POST", rec["method"])
+	assert.Equal(t, "/api/v1/servers/localhost/zones", rec["path"])
+	assert.Equal(t, float64(http.StatusUnprocessableEntity), rec["status"])
+	assert.Equal(t, "Domain name is invalid", rec["pdns_error"])
+	assert.Contains(t, rec, "duration")
+	assert.NotContains(t, rec, "request_body", "bodies should only be logged at debug level")
+	assert.NotContains(t, rec, "response_body", "bodies should only be logged at debug level")
+}
+
+func TestLoggingMiddlewareRedactsSecretsAtDebugLevel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"key-one","name":"key-one","algorithm":"hmac-sha256","key":"c2VjcmV0"}`))
+	}))
+	defer srv.Close()
+
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := NewClient(srv.URL, srv.Client(), &APIKeyAuthenticator{APIKey: "api-secret"}, io.Discard,
+		WithMiddleware(NewLoggingMiddleware(logger, LoggingConfig{})))
+
+	out := map[string]string{}
+	err := c.Post(context.Background(), "/servers/localhost/tsigkeys", &out, WithJSONRequestBody(map[string]string{"name": "key-one", "key": "c2VjcmV0"}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "c2VjcmV0", out["key"], "response body should still be decoded")
+
+	assert.NotContains(t, buf.String(), "c2VjcmV0")
+	assert.NotContains(t, buf.String(), "api-secret")
+
+	records := decodeLogRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "INFO", records[0]["level"])
+	assert.Contains(t, records[0]["request_body"], "key-one")
+	assert.Contains(t, records[0]["response_body"], "hmac-sha256")
+}
+
+func TestLoggingMiddlewareCanDisableRedaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":1,"privatekey":"Private-key-format: v1.2"}]`))
+	}))
+	defer srv.Close()
+
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard,
+		WithMiddleware(NewLoggingMiddleware(logger, LoggingConfig{DisableRedaction: true})))
+
+	require.NoError(t, c.Get(context.Background(), "/servers/localhost/zones/example.com./cryptokeys", nil))
+	assert.Contains(t, buf.String(), "Private-key-format")
+}
+
+func TestLoggingMiddlewareDoesNotBufferLargeResponses(t *testing.T) {
+	body := `{"key":"c2VjcmV0","data":"` + strings.Repeat("x", 4*maxLoggedBodySize) + `"}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard,
+		WithMiddleware(NewLoggingMiddleware(logger, LoggingConfig{})))
+
+	out := strings.Builder{}
+	require.NoError(t, c.Get(context.Background(), "/servers/localhost/zones/example.com./export", &out))
+	assert.Equal(t, body, out.String(), "response body should still be read completely")
+
+	assert.NotContains(t, buf.String(), "c2VjcmV0", "truncated bodies cannot be redacted")
+
+	records := decodeLogRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, true, records[0]["response_body_truncated"])
+	assert.NotContains(t, records[0], "response_body")
+}
+
+func TestRedactBody(t *testing.T) {
+	fields := map[string]struct{}{"key": {}, "privatekey": {}}
+
+	assert.JSONEq(t,
+		`[{"name":"foo","Key":"REDACTED","nested":{"privatekey":"REDACTED"}}]`,
+		string(redactBody([]byte(`[{"name":"foo","Key":"secret","nested":{"privatekey":"secret"}}]`), fields, false)),
+	)
+
+	assert.Equal(t, "not json", string(redactBody([]byte("not json"), fields, false)))
+}
@@ -0,0 +1,43 @@
+package pdnshttp
+
+import (
+	"net/http"
+	"time"
+)
+
+// DoFunc executes a single API request. If the API responds with an error
+// status, both the response (with its body still readable) and an error (like
+// ErrNotFound or ErrConflict) are returned.
+type DoFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware can be used to wrap each API request executed by the client; for
+// example, for logging, metrics, header injection or fault injection. The
+// request's context is available via req.Context().
+//
+// Middlewares wrap the entire request, including retries. A middleware that
+// consumes the response body must replace it, so that it can still be decoded.
+type Middleware interface {
+	Wrap(next DoFunc) DoFunc
+}
+
+// MiddlewareFunc is a function that implements the Middleware interface.
+type MiddlewareFunc func(next DoFunc) DoFunc
+
+// Wrap makes this type implement Middleware
+func (f MiddlewareFunc) Wrap(next DoFunc) DoFunc {
+	return f(next)
+}
+
+// Observer returns a middleware that invokes "fn" after each request, with the
+// request, its response and error (either of which may be nil), and the time
+// it took to execute the request.
+func Observer(fn func(req *http.Request, res *http.Response, err error, d time.Duration)) Middleware {
+	return MiddlewareFunc(func(next DoFunc) DoFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next(req)
+			fn(req, res, err, time.Since(start))
+			return res, err
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package pdnshttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewaresAreAppliedInOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "first,second", r.Header.Get("X-Trace"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	calls := make([]string, 0)
+	named := func(name string) Middleware {
+		return MiddlewareFunc(func(next DoFunc) DoFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				if h := req.Header.Get("X-Trace"); h != "" {
+					req.Header.Set("X-Trace", h+","+name)
+				} else {
+					req.Header.Set("X-Trace", name)
+				}
+
+				calls = append(calls, "before "+name)
+				res, err := next(req)
+				calls = append(calls, "after "+name)
+				return res, err
+			}
+		})
+	}
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithMiddleware(named("first"), named("second")))
+
+	err := c.Get(context.Background(), "/servers", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"before first", "before second", "after second", "after first"}, calls)
+}
+
+func TestObserverSeesResponseAndError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error": "Conflict"}`))
+	}))
+	defer srv.Close()
+
+	var (
+		observedStatus int
+		observedBody   []byte
+		observedErr    error
+		observedTime   time.Duration
+	)
+
+	obs := Observer(func(req *http.Request, res *http.Response, err error, d time.Duration) {
+		observedStatus = res.StatusCode
+		observedBody, _ = io.ReadAll(res.Body)
+		observedErr = err
+		observedTime = d
+	})
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithMiddleware(obs))
+
+	err := c.Post(context.Background(), "/servers/localhost/zones", nil)
+
+	require.Error(t, err)
+	assert.True(t, IsConflict(err))
+	assert.Equal(t, http.StatusConflict, observedStatus)
+	assert.JSONEq(t, `{"error": "Conflict"}`, string(observedBody))
+	assert.True(t, IsConflict(observedErr))
+	assert.True(t, observedTime > 0)
+}
+
+func TestMiddlewareCanInjectFaults(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	injected := errors.New("injected fault")
+	fault := MiddlewareFunc(func(next DoFunc) DoFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, injected
+		}
+	})
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithMiddleware(fault))
+
+	err := c.Get(context.Background(), "/servers", nil)
+
+	assert.Equal(t, injected, err)
+	assert.False(t, called)
+}
@@ -0,0 +1,171 @@
+package pdnshttp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit describes how many requests the client may send to the PowerDNS
+// API. A zero value for any of the fields disables the respective limit.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate at which requests may be sent.
+	// Each retry of a request (see RetryPolicy) counts as a separate request.
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that may be sent at once, exceeding
+	// RequestsPerSecond. Values smaller than 1 are treated as 1.
+	Burst int
+
+	// MaxInFlight is the maximum number of requests that may be executed
+	// concurrently.
+	//
+	// Responses that are streamed (see Client.DoStream; for example, by the
+	// zone iterators) are limited separately: at most MaxInFlight of them may
+	// be read concurrently, in addition to MaxInFlight other requests. This
+	// allows using the client while iterating over a streamed response; but
+	// nesting iterators blocks if MaxInFlight is 1.
+	MaxInFlight int
+}
+
+// limiter enforces a RateLimit; it combines a token bucket for the request
+// rate with a semaphore for the number of concurrent requests.
+type limiter struct {
+	bucket *tokenBucket
+	sem    chan struct{}
+
+	// streams limits the number of streamed responses that are read
+	// concurrently; see RateLimit.MaxInFlight.
+	streams chan struct{}
+}
+
+func newLimiter(l RateLimit) *limiter {
+	out := limiter{}
+
+	if l.RequestsPerSecond > 0 {
+		out.bucket = newTokenBucket(l.RequestsPerSecond, l.Burst)
+	}
+
+	if l.MaxInFlight > 0 {
+		out.sem = make(chan struct{}, l.MaxInFlight)
+		out.streams = make(chan struct{}, l.MaxInFlight)
+	}
+
+	return &out
+}
+
+// acquire blocks until a request may be sent, or until the context is done.
+// On success, the returned function must be called when the request has
+// completed.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// acquireStream blocks until another streamed response may be read, or until
+// the context is done. On success, the returned function must be called when
+// the response body has been closed.
+func (l *limiter) acquireStream(ctx context.Context) (func(), error) {
+	if l == nil || l.streams == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.streams <- struct{}{}:
+		return func() { <-l.streams }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// wait blocks until the request rate permits sending another request, without
+// taking a slot for a concurrent request; this is used for retries of a
+// request that already holds a slot.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil || l.bucket == nil {
+		return nil
+	}
+
+	return l.bucket.wait(ctx)
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token from the bucket, blocking until one is available or the
+// context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// isReadMethod returns true for HTTP methods that do not modify any data.
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
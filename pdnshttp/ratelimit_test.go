@@ -0,0 +1,188 @@
+package pdnshttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitDelaysRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRateLimit(RateLimit{RequestsPerSecond: 50, Burst: 1}))
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, c.Get(context.Background(), "/servers", nil))
+	}
+
+	// first request is covered by the burst; the remaining three need 20ms each
+	assert.True(t, time.Since(start) >= 55*time.Millisecond, "requests were not rate limited")
+}
+
+func TestRateLimitRespectsContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRateLimit(RateLimit{RequestsPerSecond: 0.1, Burst: 1}))
+
+	require.NoError(t, c.Get(context.Background(), "/servers", nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := c.Get(ctx, "/servers", nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestMaxInFlightLimitsConcurrency(t *testing.T) {
+	var current, peak int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRateLimit(RateLimit{MaxInFlight: 2}))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.Get(context.Background(), "/servers", nil))
+		}()
+	}
+	wg.Wait()
+
+	assert.True(t, atomic.LoadInt32(&peak) <= 2, "more than two requests were in flight")
+}
+
+func TestReadWriteRateLimitsAreSeparate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithReadWriteRateLimits(
+		RateLimit{},
+		RateLimit{RequestsPerSecond: 0.1, Burst: 1},
+	))
+
+	require.NoError(t, c.Patch(context.Background(), "/servers/localhost/zones/example.com.", nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, c.Get(ctx, "/servers", nil), "reads should not be limited")
+	}
+
+	err := c.Patch(ctx, "/servers/localhost/zones/example.com.", nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestDoStreamAllowsRequestsWhileReading(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRateLimit(RateLimit{MaxInFlight: 1}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := c.NewRequest(http.MethodGet, "/servers", nil)
+	require.NoError(t, err)
+
+	err = c.DoStream(ctx, req, func(res *http.Response) error {
+		return c.Get(ctx, "/servers", nil)
+	})
+	assert.NoError(t, err)
+}
+
+func TestRateLimitAppliesToRetries(t *testing.T) {
+	var attempts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard,
+		WithRateLimit(RateLimit{RequestsPerSecond: 50, Burst: 1}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}))
+
+	start := time.Now()
+	err := c.Get(context.Background(), "/servers", nil)
+
+	require.Error(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&attempts))
+
+	// first attempt is covered by the burst; the three retries need 20ms each
+	assert.True(t, time.Since(start) >= 55*time.Millisecond, "retries were not rate limited")
+}
+
+func TestMaxInFlightLimitsConcurrentStreams(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRateLimit(RateLimit{MaxInFlight: 1}))
+
+	stream := func(fn func(res *http.Response) error) error {
+		req, err := c.NewRequest(http.MethodGet, "/servers", nil)
+		require.NoError(t, err)
+
+		return c.DoStream(context.Background(), req, fn)
+	}
+
+	reading := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		assert.NoError(t, stream(func(*http.Response) error {
+			close(reading)
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "second stream should wait for the first one")
+			return nil
+		}))
+	}()
+
+	<-reading
+	assert.NoError(t, stream(func(*http.Response) error { return nil }))
+	<-done
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
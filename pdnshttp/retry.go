@@ -0,0 +1,223 @@
+package pdnshttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes if and how failed requests are retried. A request is
+// retried when the HTTP round trip fails with a transport error, or when the
+// server responds with one of the RetryableStatusCodes; and only if the
+// request is considered idempotent (see IsIdempotent).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts (including the first one).
+	// Values smaller than 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the time to wait between two attempts.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the backoff grows with each attempt.
+	Multiplier float64
+
+	// Jitter is the fraction (between 0 and 1) by which each backoff is
+	// randomly shortened, to avoid many clients retrying in lockstep.
+	Jitter float64
+
+	// RetryableMethods contains the HTTP methods that are considered
+	// idempotent and may be retried. When nil, DefaultRetryableMethods is used.
+	RetryableMethods []string
+
+	// RetryableStatusCodes contains the HTTP status codes on which a request
+	// is retried. When nil, DefaultRetryableStatusCodes is used.
+	RetryableStatusCodes []int
+
+	// IsIdempotent can be used to override the method-based idempotency rules
+	// for individual requests; for example, to allow retrying a specific POST
+	// request. When nil, only RetryableMethods is consulted.
+	IsIdempotent func(req *http.Request) bool
+}
+
+// DefaultRetryableMethods contains the HTTP methods that are retried by
+// default. POST (which is used to create zones, keys, etc.) and PATCH are
+// deliberately not part of this list.
+var DefaultRetryableMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// DefaultRetryableStatusCodes contains the HTTP status codes that are retried
+// by default.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults: up to four
+// attempts with exponential backoff, starting at 250ms and capped at 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+func (p *RetryPolicy) idempotent(req *http.Request) bool {
+	if p.IsIdempotent != nil {
+		return p.IsIdempotent(req)
+	}
+
+	methods := p.RetryableMethods
+	if methods == nil {
+		methods = DefaultRetryableMethods
+	}
+
+	for _, m := range methods {
+		if m == req.Method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shouldRetry decides if another attempt should be made after the given
+// attempt (starting at 1) returned "res" and "err".
+func (p *RetryPolicy) shouldRetry(req *http.Request, attempt int, res *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if !p.idempotent(req) {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return p.retryableStatus(res.StatusCode)
+}
+
+// backoff returns the time to wait after the given attempt (starting at 1).
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = DefaultRetryPolicy().InitialBackoff
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		d -= d * math.Min(p.Jitter, 1) * rand.Float64()
+	}
+
+	wait := time.Duration(d)
+
+	if ra, ok := retryAfter(res); ok && ra > wait {
+		wait = ra
+	}
+
+	return wait
+}
+
+// retryAfter parses the "Retry-After" header of a response, which may contain
+// either a number of seconds or an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	h := res.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(h); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(h); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
+
+// rewindBody prepares a request for another attempt by restoring its body.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	return nil
+}
+
+// sleep blocks for the given duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func discardBody(res *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+	_ = res.Body.Close()
+}
@@ -0,0 +1,150 @@
+package pdnshttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"foo":"bar"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRetryPolicy(testRetryPolicy()))
+
+	var out map[string]string
+	err := c.Get(context.Background(), "/servers", &out)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, "bar", out["foo"])
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRetryPolicy(testRetryPolicy()))
+
+	err := c.Get(context.Background(), "/servers", nil)
+
+	require.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryDoesNotRetryPostByDefault(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRetryPolicy(testRetryPolicy()))
+
+	err := c.Post(context.Background(), "/servers/localhost/zones", nil, WithJSONRequestBody(map[string]string{"name": "example.com."}))
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryReplaysRequestBody(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"example.com."}`, string(body))
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	policy := testRetryPolicy()
+	policy.RetryableMethods = []string{http.MethodPost}
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRetryPolicy(policy))
+
+	err := c.Post(context.Background(), "/servers/localhost/zones", nil, WithJSONRequestBody(map[string]string{"name": "example.com."}))
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRetryPolicy(testRetryPolicy()))
+
+	err := c.Get(context.Background(), "/servers", nil)
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryStopsWhenContextIsCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), nil, io.Discard, WithRetryPolicy(testRetryPolicy()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.Get(ctx, "/servers", nil)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRetryBackoffHonorsRetryAfter(t *testing.T) {
+	p := testRetryPolicy()
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	assert.Equal(t, 2*time.Second, p.backoff(1, res))
+
+	assert.Equal(t, time.Millisecond, p.backoff(1, nil))
+	assert.Equal(t, 2*time.Millisecond, p.backoff(2, nil))
+	assert.Equal(t, 5*time.Millisecond, p.backoff(10, nil))
+}
@@ -0,0 +1,22 @@
+package pdnstest
+
+import (
+	"net/http"
+
+	"github.com/mittwald/go-powerdns/apis/cache"
+)
+
+func (s *Server) flushCache(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	domain := r.URL.Query().Get("domain")
+	if domain == "" {
+		writeError(w, http.StatusUnprocessableEntity, "No domain given")
+		return
+	}
+
+	s.flushedCaches = append(s.flushedCaches, domain)
+	writeJSON(w, http.StatusOK, cache.FlushResult{Count: 0, Result: "Flushed cache."})
+}
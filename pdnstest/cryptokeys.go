@@ -0,0 +1,159 @@
+package pdnstest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mittwald/go-powerdns/apis/cryptokeys"
+)
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return b
+}
+
+func randomBase64(n int) string {
+	return base64.StdEncoding.EncodeToString(randomBytes(n))
+}
+
+// lookupCryptokey returns the index of the cryptokey addressed by the
+// request, or writes an error response and returns -1.
+func lookupCryptokey(w http.ResponseWriter, r *http.Request, z *zoneState) int {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err == nil {
+		for i := range z.cryptokeys {
+			if z.cryptokeys[i].ID == id {
+				return i
+			}
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find cryptokey '%s'", r.PathValue("id")))
+	return -1
+}
+
+func (s *Server) listCryptokeys(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	out := make([]cryptokeys.Cryptokey, 0, len(z.cryptokeys))
+
+	for _, k := range z.cryptokeys {
+		k.PrivateKey = ""
+		out = append(out, k)
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createCryptokey(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	in := cryptokeys.Cryptokey{}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	key := in
+	key.ID = s.nextKeyID
+	key.Type = "Cryptokey"
+	key.Published = true
+
+	if key.KeyType == "" {
+		key.KeyType = "csk"
+	}
+
+	if key.Algorithm == "" {
+		key.Algorithm = "ECDSAP256SHA256"
+	}
+
+	if key.Bits == 0 {
+		key.Bits = 256
+	}
+
+	if key.PrivateKey == "" {
+		key.PrivateKey = "Private-key-format: v1.2\nAlgorithm: 13 (ECDSAP256SHA256)\nPrivateKey: " + randomBase64(32) + "\n"
+	}
+
+	flags := 257
+	if key.KeyType == "zsk" {
+		flags = 256
+	}
+
+	key.DNSKey = fmt.Sprintf("%d 3 13 %s", flags, randomBase64(64))
+
+	if key.KeyType != "zsk" {
+		key.DS = []string{fmt.Sprintf("%d 13 2 %x", 10000+key.ID, randomBytes(32))}
+	}
+
+	s.nextKeyID++
+	z.cryptokeys = append(z.cryptokeys, key)
+
+	writeJSON(w, http.StatusCreated, key)
+}
+
+func (s *Server) getCryptokey(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	idx := lookupCryptokey(w, r, z)
+	if idx < 0 {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, z.cryptokeys[idx])
+}
+
+func (s *Server) toggleCryptokey(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	idx := lookupCryptokey(w, r, z)
+	if idx < 0 {
+		return
+	}
+
+	// PowerDNS expects the desired state in the request body; requests
+	// without a body simply flip the key's state.
+	in := struct {
+		Active *bool `json:"active"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err == nil && in.Active != nil {
+		z.cryptokeys[idx].Active = *in.Active
+	} else {
+		z.cryptokeys[idx].Active = !z.cryptokeys[idx].Active
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteCryptokey(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	idx := lookupCryptokey(w, r, z)
+	if idx < 0 {
+		return
+	}
+
+	z.cryptokeys = append(z.cryptokeys[:idx], z.cryptokeys[idx+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,15 @@
+// Package pdnstest provides an in-memory fake of the PowerDNS Authoritative HTTP API,
+// for testing code that uses this client library without running an actual PowerDNS
+// server.
+//
+// The fake server is stateful: zones that are created can subsequently be retrieved,
+// modified and deleted. It implements zones (including rrset PATCH semantics and
+// exports), metadata, cryptokeys, TSIG keys, views, networks, searching and cache
+// flushes, and responds with the same status codes as PowerDNS in common error cases.
+// It does not implement DNSSEC signing, zone transfers or any actual DNS service.
+//
+//	srv := pdnstest.NewServer()
+//	defer srv.Close()
+//
+//	client, err := srv.NewClient()
+package pdnstest
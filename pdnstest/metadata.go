@@ -0,0 +1,142 @@
+package pdnstest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mittwald/go-powerdns/apis/metadata"
+)
+
+func findMetadata(list []metadata.Metadata, kind string) int {
+	for i := range list {
+		if list[i].Kind == kind {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// checkWritableKind writes an error response and returns false if a metadata
+// kind may not be modified via the metadata endpoint.
+func checkWritableKind(w http.ResponseWriter, kind string) bool {
+	if metadata.IsReadOnlyHTTP(kind) || metadata.IsNotViaHTTP(kind) {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Metadata kind '%s' cannot be modified via the API", kind))
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) listMetadata(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	out := make([]metadata.Metadata, 0, len(z.metadata))
+	out = append(out, z.metadata...)
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createMetadata(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	in := metadata.Metadata{}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if !checkWritableKind(w, in.Kind) {
+		return
+	}
+
+	idx := findMetadata(z.metadata, in.Kind)
+	if idx < 0 {
+		z.metadata = append(z.metadata, metadata.Metadata{Kind: in.Kind, Metadata: []string{}})
+		idx = len(z.metadata) - 1
+	}
+
+	for _, v := range in.Metadata {
+		if !containsString(z.metadata[idx].Metadata, v) {
+			z.metadata[idx].Metadata = append(z.metadata[idx].Metadata, v)
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, z.metadata[idx])
+}
+
+func (s *Server) getMetadata(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	kind := r.PathValue("kind")
+	out := metadata.Metadata{Kind: kind, Metadata: []string{}}
+
+	if idx := findMetadata(z.metadata, kind); idx >= 0 {
+		out.Metadata = append(out.Metadata, z.metadata[idx].Metadata...)
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) replaceMetadata(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	kind := r.PathValue("kind")
+	if !checkWritableKind(w, kind) {
+		return
+	}
+
+	in := metadata.Metadata{}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	md := metadata.Metadata{Kind: kind, Metadata: append([]string{}, in.Metadata...)}
+
+	if idx := findMetadata(z.metadata, kind); idx >= 0 {
+		z.metadata[idx] = md
+	} else {
+		z.metadata = append(z.metadata, md)
+	}
+
+	writeJSON(w, http.StatusOK, md)
+}
+
+func (s *Server) deleteMetadata(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	kind := r.PathValue("kind")
+	if !checkWritableKind(w, kind) {
+		return
+	}
+
+	if idx := findMetadata(z.metadata, kind); idx >= 0 {
+		z.metadata = append(z.metadata[:idx], z.metadata[idx+1:]...)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+package pdnstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+
+	"github.com/mittwald/go-powerdns/apis/networks"
+)
+
+// parseNetwork returns the normalized form of the network addressed by the
+// request, or writes an error response and returns false.
+func parseNetwork(w http.ResponseWriter, r *http.Request) (string, bool) {
+	prefix, err := netip.ParsePrefix(r.PathValue("ip") + "/" + r.PathValue("prefixlen"))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid network: %s", err))
+		return "", false
+	}
+
+	return prefix.Masked().String(), true
+}
+
+func (s *Server) listNetworks(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	out := struct {
+		Networks []networks.NetworkView `json:"networks"`
+	}{Networks: make([]networks.NetworkView, 0, len(s.networks))}
+
+	for network, view := range s.networks {
+		out.Networks = append(out.Networks, networks.NetworkView{Network: network, View: view})
+	}
+
+	sort.Slice(out.Networks, func(i, j int) bool { return out.Networks[i].Network < out.Networks[j].Network })
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	network, ok := parseNetwork(w, r)
+	if !ok {
+		return
+	}
+
+	view, ok := s.networks[network]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Network '%s' does not exist", network))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, networks.NetworkView{Network: network, View: view})
+}
+
+func (s *Server) setNetwork(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	network, ok := parseNetwork(w, r)
+	if !ok {
+		return
+	}
+
+	in := struct {
+		View string `json:"view"`
+	}{}
+
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if in.View == "" {
+		delete(s.networks, network)
+	} else {
+		s.networks[network] = in.View
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package pdnstest
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// searchResult mirrors search.Result, but encodes the object type as a
+// string, as PowerDNS does.
+type searchResult struct {
+	Content    string `json:"content,omitempty"`
+	Disabled   bool   `json:"disabled"`
+	Name       string `json:"name"`
+	ObjectType string `json:"object_type"`
+	ZoneID     string `json:"zone_id"`
+	Zone       string `json:"zone,omitempty"`
+	Type       string `json:"type,omitempty"`
+	TTL        int    `json:"ttl,omitempty"`
+}
+
+// searchPattern converts a PowerDNS search query (which supports "*" and "?"
+// as wildcards) into a case-insensitive regular expression.
+func searchPattern(q string) *regexp.Regexp {
+	expr := strings.Builder{}
+	expr.WriteString("(?i)^")
+
+	for _, c := range q {
+		switch c {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	q := r.URL.Query()
+	pattern := searchPattern(q.Get("q"))
+	objectType := q.Get("object_type")
+	if objectType == "" {
+		objectType = "all"
+	}
+
+	max, err := strconv.Atoi(q.Get("max"))
+	if err != nil || max <= 0 {
+		max = 100
+	}
+
+	ids := make([]string, 0, len(s.zones))
+	for id := range s.zones {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	want := func(t string) bool {
+		return objectType == "all" || objectType == t
+	}
+
+	out := make([]searchResult, 0)
+
+	for _, id := range ids {
+		z := s.zones[id].zone
+
+		if want("zone") && pattern.MatchString(z.Name) {
+			out = append(out, searchResult{Name: z.Name, ObjectType: "zone", ZoneID: z.ID})
+		}
+
+		for _, set := range z.ResourceRecordSets {
+			if want("record") {
+				for _, rec := range set.Records {
+					if pattern.MatchString(set.Name) || pattern.MatchString(rec.Content) {
+						out = append(out, searchResult{
+							Content:    rec.Content,
+							Disabled:   rec.Disabled,
+							Name:       set.Name,
+							ObjectType: "record",
+							ZoneID:     z.ID,
+							Zone:       z.Name,
+							Type:       set.Type,
+							TTL:        set.TTL,
+						})
+					}
+				}
+			}
+
+			if want("comment") {
+				for _, c := range set.Comments {
+					if pattern.MatchString(set.Name) || pattern.MatchString(c.Content) {
+						out = append(out, searchResult{
+							Content:    c.Content,
+							Name:       set.Name,
+							ObjectType: "comment",
+							ZoneID:     z.ID,
+							Zone:       z.Name,
+							Type:       set.Type,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	if len(out) > max {
+		out = out[:max]
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
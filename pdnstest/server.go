@@ -0,0 +1,229 @@
+package pdnstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/apis/cryptokeys"
+	"github.com/mittwald/go-powerdns/apis/metadata"
+	"github.com/mittwald/go-powerdns/apis/tsigkey"
+	"github.com/mittwald/go-powerdns/apis/zones"
+)
+
+// DefaultServerID is the ID of the (only) server known to the fake API.
+const DefaultServerID = "localhost"
+
+// DefaultAPIKey is the API key expected by the fake API.
+const DefaultAPIKey = "secret"
+
+// Server is a fake PowerDNS API server, backed by an httptest.Server.
+type Server struct {
+	// URL is the base URL of the fake API, for use with pdns.WithBaseURL.
+	URL string
+
+	// APIKey is the API key that requests need to supply; if empty, requests
+	// are not authenticated.
+	APIKey string
+
+	// ServerID is the ID of the (only) server known to the fake API.
+	ServerID string
+
+	srv *httptest.Server
+
+	mu            sync.Mutex
+	zones         map[string]*zoneState
+	tsigKeys      map[string]*tsigkey.TSIGKey
+	views         map[string][]string
+	networks      map[string]string
+	nextKeyID     int
+	flushedCaches []string
+}
+
+type zoneState struct {
+	zone       zones.Zone
+	metadata   []metadata.Metadata
+	cryptokeys []cryptokeys.Cryptokey
+}
+
+// Option configures a fake server.
+type Option func(s *Server)
+
+// WithAPIKey sets the API key that requests need to supply. Use an empty
+// string to disable authentication.
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.APIKey = key
+	}
+}
+
+// WithServerID sets the ID of the server known to the fake API.
+func WithServerID(id string) Option {
+	return func(s *Server) {
+		s.ServerID = id
+	}
+}
+
+// NewServer starts a new fake PowerDNS API server. The caller should call
+// Close when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := Server{
+		APIKey:    DefaultAPIKey,
+		ServerID:  DefaultServerID,
+		zones:     map[string]*zoneState{},
+		tsigKeys:  map[string]*tsigkey.TSIGKey{},
+		views:     map[string][]string{},
+		networks:  map[string]string{},
+		nextKeyID: 1,
+	}
+
+	for i := range opts {
+		opts[i](&s)
+	}
+
+	s.srv = httptest.NewServer(s.handler())
+	s.URL = s.srv.URL
+
+	return &s
+}
+
+// Close shuts down the fake server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// NewClient returns a PowerDNS client that is configured to use the fake
+// server. Additional client options may be supplied.
+func (s *Server) NewClient(opts ...pdns.ClientOption) (pdns.Client, error) {
+	base := []pdns.ClientOption{
+		pdns.WithBaseURL(s.URL),
+		pdns.WithHTTPClient(s.srv.Client()),
+		pdns.WithAPIKeyAuthentication(s.APIKey),
+	}
+
+	return pdns.New(append(base, opts...)...)
+}
+
+// Zone returns a copy of the current state of a zone, for making assertions
+// in tests without going through the API.
+func (s *Server) Zone(zoneID string) (*zones.Zone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[zoneID]
+	if !ok {
+		return nil, false
+	}
+
+	out := copyZone(z.zone)
+	return &out, true
+}
+
+// FlushedCaches returns the names of all domains for which a cache flush has
+// been requested.
+func (s *Server) FlushedCaches() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.flushedCaches...)
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	prefix := "/api/v1/servers/{server}"
+
+	mux.HandleFunc("GET /api/v1/servers", s.listServers)
+	mux.HandleFunc("GET "+prefix, s.getServer)
+
+	mux.HandleFunc("GET "+prefix+"/zones", s.listZones)
+	mux.HandleFunc("POST "+prefix+"/zones", s.createZone)
+	mux.HandleFunc("GET "+prefix+"/zones/{zone}", s.getZone)
+	mux.HandleFunc("PATCH "+prefix+"/zones/{zone}", s.patchZone)
+	mux.HandleFunc("PUT "+prefix+"/zones/{zone}", s.modifyZone)
+	mux.HandleFunc("DELETE "+prefix+"/zones/{zone}", s.deleteZone)
+	mux.HandleFunc("GET "+prefix+"/zones/{zone}/export", s.exportZone)
+	mux.HandleFunc("PUT "+prefix+"/zones/{zone}/notify", s.notifyZone)
+	mux.HandleFunc("PUT "+prefix+"/zones/{zone}/axfr-retrieve", s.retrieveZone)
+	mux.HandleFunc("PUT "+prefix+"/zones/{zone}/rectify", s.rectifyZone)
+	mux.HandleFunc("GET "+prefix+"/zones/{zone}/check", s.checkZone)
+
+	mux.HandleFunc("GET "+prefix+"/zones/{zone}/metadata", s.listMetadata)
+	mux.HandleFunc("POST "+prefix+"/zones/{zone}/metadata", s.createMetadata)
+	mux.HandleFunc("GET "+prefix+"/zones/{zone}/metadata/{kind}", s.getMetadata)
+	mux.HandleFunc("PUT "+prefix+"/zones/{zone}/metadata/{kind}", s.replaceMetadata)
+	mux.HandleFunc("DELETE "+prefix+"/zones/{zone}/metadata/{kind}", s.deleteMetadata)
+
+	mux.HandleFunc("GET "+prefix+"/zones/{zone}/cryptokeys", s.listCryptokeys)
+	mux.HandleFunc("POST "+prefix+"/zones/{zone}/cryptokeys", s.createCryptokey)
+	mux.HandleFunc("GET "+prefix+"/zones/{zone}/cryptokeys/{id}", s.getCryptokey)
+	mux.HandleFunc("PUT "+prefix+"/zones/{zone}/cryptokeys/{id}", s.toggleCryptokey)
+	mux.HandleFunc("DELETE "+prefix+"/zones/{zone}/cryptokeys/{id}", s.deleteCryptokey)
+
+	mux.HandleFunc("GET "+prefix+"/tsigkeys", s.listTSIGKeys)
+	mux.HandleFunc("POST "+prefix+"/tsigkeys", s.createTSIGKey)
+	mux.HandleFunc("GET "+prefix+"/tsigkeys/{id}", s.getTSIGKey)
+	mux.HandleFunc("PUT "+prefix+"/tsigkeys/{id}", s.updateTSIGKey)
+	mux.HandleFunc("DELETE "+prefix+"/tsigkeys/{id}", s.deleteTSIGKey)
+
+	mux.HandleFunc("GET "+prefix+"/views", s.listViews)
+	mux.HandleFunc("GET "+prefix+"/views/{view}", s.listViewZones)
+	mux.HandleFunc("POST "+prefix+"/views/{view}", s.addZoneToView)
+	mux.HandleFunc("DELETE "+prefix+"/views/{view}/{id}", s.removeZoneFromView)
+
+	mux.HandleFunc("GET "+prefix+"/networks", s.listNetworks)
+	mux.HandleFunc("GET "+prefix+"/networks/{ip}/{prefixlen}", s.getNetwork)
+	mux.HandleFunc("PUT "+prefix+"/networks/{ip}/{prefixlen}", s.setNetwork)
+
+	mux.HandleFunc("GET "+prefix+"/search-data", s.search)
+	mux.HandleFunc("PUT "+prefix+"/cache/flush", s.flushCache)
+
+	return s.authenticate(mux)
+}
+
+// authenticate rejects requests without a valid API key, and requests for
+// unknown servers.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.APIKey != "" && r.Header.Get("X-API-Key") != s.APIKey {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Unauthorized"))
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) checkServer(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("server") != s.ServerID {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Could not parse JSON body: "+err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,389 @@
+package pdnstest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/apis/cryptokeys"
+	"github.com/mittwald/go-powerdns/apis/metadata"
+	"github.com/mittwald/go-powerdns/apis/search"
+	"github.com/mittwald/go-powerdns/apis/tsigkey"
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/mittwald/go-powerdns/pdnstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) (*pdnstest.Server, pdns.Client) {
+	srv := pdnstest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	return srv, c
+}
+
+func createZone(t *testing.T, c pdns.Client, name string) *zones.Zone {
+	created, err := c.Zones().CreateZone(context.Background(), "localhost", zones.Zone{
+		Name:        name,
+		Kind:        zones.ZoneKindNative,
+		Nameservers: []string{"ns1.example.com.", "ns2.example.com."},
+		ResourceRecordSets: []zones.ResourceRecordSet{
+			{Name: name, Type: "A", TTL: 60, Records: []zones.Record{{Content: "127.0.0.1"}}},
+		},
+	})
+
+	require.Nil(t, err)
+	return created
+}
+
+func TestServersCanBeListed(t *testing.T) {
+	_, c := setup(t)
+
+	s, err := c.Servers().ListServers(context.Background())
+
+	require.Nil(t, err)
+	require.Len(t, s, 1)
+	assert.Equal(t, "localhost", s[0].ID)
+}
+
+func TestRequestsWithWrongAPIKeyAreRejected(t *testing.T) {
+	srv := pdnstest.NewServer()
+	defer srv.Close()
+
+	c, err := pdns.New(pdns.WithBaseURL(srv.URL), pdns.WithAPIKeyAuthentication("wrong"))
+	require.Nil(t, err)
+
+	_, err = c.Zones().ListZones(context.Background(), "localhost")
+
+	require.NotNil(t, err)
+	assert.True(t, pdnshttp.IsUnauthorized(err))
+}
+
+func TestZoneLifecycle(t *testing.T) {
+	srv, c := setup(t)
+	ctx := context.Background()
+
+	created := createZone(t, c, "example.de.")
+	assert.Equal(t, "example.de.", created.ID)
+	assert.NotZero(t, created.Serial)
+
+	z, err := c.Zones().GetZone(ctx, "localhost", "example.de.")
+	require.Nil(t, err)
+
+	assert.NotNil(t, z.GetRecordSet("example.de.", "SOA"))
+	require.NotNil(t, z.GetRecordSet("example.de.", "NS"))
+	assert.Len(t, z.GetRecordSet("example.de.", "NS").Records, 2)
+
+	list, err := c.Zones().ListZones(ctx, "localhost")
+	require.Nil(t, err)
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].ResourceRecordSets)
+
+	err = c.Zones().DeleteZone(ctx, "localhost", "example.de.")
+	require.Nil(t, err)
+
+	_, ok := srv.Zone("example.de.")
+	assert.False(t, ok)
+
+	_, err = c.Zones().GetZone(ctx, "localhost", "example.de.")
+	assert.True(t, pdnshttp.IsNotFound(err))
+}
+
+func TestCreatingDuplicateZoneReturnsConflict(t *testing.T) {
+	_, c := setup(t)
+
+	createZone(t, c, "example.de.")
+	_, err := c.Zones().CreateZone(context.Background(), "localhost", zones.Zone{Name: "example.de.", Kind: zones.ZoneKindNative})
+
+	require.NotNil(t, err)
+	assert.True(t, pdnshttp.IsConflict(err))
+}
+
+func TestCreatingNonCanonicalZoneIsUnprocessable(t *testing.T) {
+	_, c := setup(t)
+
+	_, err := c.Zones().CreateZone(context.Background(), "localhost", zones.Zone{Name: "example.de", Kind: zones.ZoneKindNative})
+
+	require.NotNil(t, err)
+	assert.True(t, pdnshttp.IsUnprocessable(err))
+}
+
+func TestRecordSetsCanBeReplacedAndRemoved(t *testing.T) {
+	srv, c := setup(t)
+	ctx := context.Background()
+
+	created := createZone(t, c, "example.de.")
+
+	err := c.Zones().AddRecordSetToZone(ctx, "localhost", "example.de.", zones.ResourceRecordSet{
+		Name:    "www.example.de.",
+		Type:    "A",
+		TTL:     300,
+		Records: []zones.Record{{Content: "127.0.0.2"}, {Content: "127.0.0.3"}},
+	})
+	require.Nil(t, err)
+
+	z, _ := srv.Zone("example.de.")
+	require.NotNil(t, z.GetRecordSet("www.example.de.", "A"))
+	assert.Len(t, z.GetRecordSet("www.example.de.", "A").Records, 2)
+	assert.Equal(t, created.Serial+1, z.Serial)
+
+	err = c.Zones().RemoveRecordSetFromZone(ctx, "localhost", "example.de.", "www.example.de.", "A")
+	require.Nil(t, err)
+
+	z, _ = srv.Zone("example.de.")
+	assert.Nil(t, z.GetRecordSet("www.example.de.", "A"))
+}
+
+func TestInvalidPatchIsRejectedAtomically(t *testing.T) {
+	srv, c := setup(t)
+	ctx := context.Background()
+
+	createZone(t, c, "example.de.")
+
+	err := c.Zones().AddRecordSetsToZone(ctx, "localhost", "example.de.", []zones.ResourceRecordSet{
+		{Name: "www.example.de.", Type: "A", TTL: 300, Records: []zones.Record{{Content: "127.0.0.2"}}},
+		{Name: "www.example.com.", Type: "A", TTL: 300, Records: []zones.Record{{Content: "127.0.0.2"}}},
+	})
+
+	require.NotNil(t, err)
+	assert.True(t, pdnshttp.IsUnprocessable(err))
+
+	z, _ := srv.Zone("example.de.")
+	assert.Nil(t, z.GetRecordSet("www.example.de.", "A"))
+}
+
+func TestCNAMEConflictsAreRejected(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+
+	createZone(t, c, "example.de.")
+
+	err := c.Zones().AddRecordSetToZone(ctx, "localhost", "example.de.", zones.ResourceRecordSet{
+		Name:    "example.de.",
+		Type:    "CNAME",
+		TTL:     300,
+		Records: []zones.Record{{Content: "example.com."}},
+	})
+
+	require.NotNil(t, err)
+	assert.True(t, pdnshttp.IsUnprocessable(err))
+}
+
+func TestZoneCanBeExported(t *testing.T) {
+	_, c := setup(t)
+
+	createZone(t, c, "example.de.")
+	out, err := c.Zones().ExportZone(context.Background(), "localhost", "example.de.")
+
+	require.Nil(t, err)
+	assert.Contains(t, string(out), "example.de.\t60\tIN\tA\t127.0.0.1\n")
+}
+
+func TestMetadataCanBeManaged(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+
+	createZone(t, c, "example.de.")
+
+	err := c.Metadata().Create(ctx, "localhost", "example.de.", metadata.Metadata{Kind: "X-Test", Metadata: []string{"a"}})
+	require.Nil(t, err)
+
+	md, err := c.Metadata().Replace(ctx, "localhost", "example.de.", "X-Test", metadata.Metadata{Metadata: []string{"b", "c"}})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"b", "c"}, md.Metadata)
+
+	list, err := c.Metadata().List(ctx, "localhost", "example.de.")
+	require.Nil(t, err)
+	assert.Len(t, list, 1)
+
+	err = c.Metadata().Delete(ctx, "localhost", "example.de.", "X-Test")
+	require.Nil(t, err)
+
+	md, err = c.Metadata().Get(ctx, "localhost", "example.de.", "X-Test")
+	require.Nil(t, err)
+	assert.Empty(t, md.Metadata)
+}
+
+func TestCryptokeysCanBeManaged(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+
+	createZone(t, c, "example.de.")
+
+	key, err := c.Cryptokeys().CreateCryptokey(ctx, "localhost", "example.de.", cryptokeys.Cryptokey{KeyType: "ksk", Active: true})
+	require.Nil(t, err)
+	assert.NotEmpty(t, key.PrivateKey)
+
+	list, err := c.Cryptokeys().ListCryptokeys(ctx, "localhost", "example.de.")
+	require.Nil(t, err)
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].PrivateKey)
+
+	err = c.Cryptokeys().ToggleCryptokey(ctx, "localhost", "example.de.", key.ID)
+	require.Nil(t, err)
+
+	got, err := c.Cryptokeys().GetCryptokey(ctx, "localhost", "example.de.", key.ID)
+	require.Nil(t, err)
+	assert.False(t, got.Active)
+
+	err = c.Cryptokeys().DeleteCryptokey(ctx, "localhost", "example.de.", key.ID)
+	require.Nil(t, err)
+
+	_, err = c.Cryptokeys().GetCryptokey(ctx, "localhost", "example.de.", key.ID)
+	assert.True(t, pdnshttp.IsNotFound(err))
+}
+
+func TestTSIGKeysCanBeManaged(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+
+	key, err := c.TsigKeys().CreateTSIGKey(ctx, "localhost", tsigkey.TSIGKey{Name: "test", Algorithm: "hmac-sha256"})
+	require.Nil(t, err)
+	assert.Equal(t, "test.", key.ID)
+	assert.NotEmpty(t, key.Key)
+
+	_, err = c.TsigKeys().CreateTSIGKey(ctx, "localhost", tsigkey.TSIGKey{Name: "test", Algorithm: "hmac-sha256"})
+	assert.True(t, pdnshttp.IsConflict(err))
+
+	list, err := c.TsigKeys().ListTSIGKey(ctx, "localhost")
+	require.Nil(t, err)
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].Key)
+
+	err = c.TsigKeys().DeleteTSIGKey(ctx, "localhost", key.ID)
+	require.Nil(t, err)
+
+	_, err = c.TsigKeys().GetTSIGKey(ctx, "localhost", key.ID)
+	assert.True(t, pdnshttp.IsNotFound(err))
+}
+
+func TestViewsAndNetworksCanBeManaged(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+
+	createZone(t, c, "example.de.")
+
+	err := c.Views().AddZoneToView(ctx, "localhost", "internal", "example.de.")
+	require.Nil(t, err)
+
+	zl, err := c.Views().ListViewZones(ctx, "localhost", "internal")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.de."}, zl.Zones)
+
+	err = c.Networks().SetNetworkView(ctx, "localhost", "192.0.2.1", 24, "internal")
+	require.Nil(t, err)
+
+	nv, err := c.Networks().GetNetworkView(ctx, "localhost", "192.0.2.0", 24)
+	require.Nil(t, err)
+	assert.Equal(t, "192.0.2.0/24", nv.Network)
+	assert.Equal(t, "internal", nv.View)
+
+	err = c.Views().RemoveZoneFromView(ctx, "localhost", "internal", "example.de.")
+	require.Nil(t, err)
+
+	vl, err := c.Views().ListViews(ctx, "localhost")
+	require.Nil(t, err)
+	assert.Empty(t, vl.Views)
+}
+
+func TestSearchSupportsWildcards(t *testing.T) {
+	_, c := setup(t)
+
+	createZone(t, c, "example.de.")
+
+	results, err := c.Search().Search(context.Background(), "localhost", "EXAMPLE.*", 10, search.ObjectTypeAll)
+	require.Nil(t, err)
+
+	assert.Len(t, results.FilterByObjectType(search.ObjectTypeZone), 1)
+	assert.Len(t, results.FilterByRecordType("A"), 1)
+}
+
+func TestCacheFlushIsRecorded(t *testing.T) {
+	srv, c := setup(t)
+
+	res, err := c.Cache().Flush(context.Background(), "localhost", "example.de.")
+	require.Nil(t, err)
+
+	assert.Equal(t, "Flushed cache.", res.Result)
+	assert.Equal(t, []string{"example.de."}, srv.FlushedCaches())
+}
+
+func TestRecordsCanBeAddedToAndRemovedFromSets(t *testing.T) {
+	srv, c := setup(t)
+	ctx := context.Background()
+
+	createZone(t, c, "example.de.")
+
+	err := c.Zones().AddRecordsToSet(ctx, "localhost", "example.de.", zones.ResourceRecordSet{
+		Name:    "example.de.",
+		Type:    "A",
+		Records: []zones.Record{{Content: "127.0.0.2"}, {Content: "127.0.0.1"}},
+	})
+	require.Nil(t, err)
+
+	z, _ := srv.Zone("example.de.")
+	assert.Equal(t, []zones.Record{{Content: "127.0.0.1"}, {Content: "127.0.0.2"}}, z.GetRecordSet("example.de.", "A").Records)
+	assert.Equal(t, 60, z.GetRecordSet("example.de.", "A").TTL)
+
+	err = c.Zones().RemoveRecordsFromSet(ctx, "localhost", "example.de.", zones.ResourceRecordSet{
+		Name:    "example.de.",
+		Type:    "A",
+		Records: []zones.Record{{Content: "127.0.0.1"}},
+	})
+	require.Nil(t, err)
+
+	z, _ = srv.Zone("example.de.")
+	assert.Equal(t, []zones.Record{{Content: "127.0.0.2"}}, z.GetRecordSet("example.de.", "A").Records)
+
+	err = c.Zones().RemoveRecordsFromSet(ctx, "localhost", "example.de.", zones.ResourceRecordSet{
+		Name:    "example.de.",
+		Type:    "A",
+		Records: []zones.Record{{Content: "127.0.0.2"}},
+	})
+	require.Nil(t, err)
+
+	z, _ = srv.Zone("example.de.")
+	assert.Nil(t, z.GetRecordSet("example.de.", "A"))
+}
+
+func TestExtendingNewRecordSetRequiresTTL(t *testing.T) {
+	_, c := setup(t)
+
+	createZone(t, c, "example.de.")
+
+	err := c.Zones().AddRecordsToSet(context.Background(), "localhost", "example.de.", zones.ResourceRecordSet{
+		Name:    "www.example.de.",
+		Type:    "A",
+		Records: []zones.Record{{Content: "127.0.0.2"}},
+	})
+
+	require.NotNil(t, err)
+	assert.True(t, pdnshttp.IsUnprocessable(err))
+}
+
+func TestZoneAccountCanBeCleared(t *testing.T) {
+	srv, c := setup(t)
+	createZone(t, c, "example.com.")
+
+	err := c.Zones().ModifyBasicZoneData(context.Background(), "localhost", "example.com.", zones.ZoneBasicDataUpdate{Account: "customer"})
+	require.Nil(t, err)
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/api/v1/servers/localhost/zones/example.com.", strings.NewReader(`{"account": ""}`))
+	require.Nil(t, err)
+	req.Header.Set("X-API-Key", pdnstest.DefaultAPIKey)
+
+	res, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	z, ok := srv.Zone("example.com.")
+	require.True(t, ok)
+	assert.Equal(t, "", z.Account)
+}
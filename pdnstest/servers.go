@@ -0,0 +1,31 @@
+package pdnstest
+
+import (
+	"net/http"
+
+	"github.com/mittwald/go-powerdns/apis/servers"
+)
+
+func (s *Server) server() servers.Server {
+	return servers.Server{
+		ID:         s.ServerID,
+		Type:       "Server",
+		DaemonType: "authoritative",
+		Version:    "pdnstest",
+		URL:        "/api/v1/servers/" + s.ServerID,
+		ConfigURL:  "/api/v1/servers/" + s.ServerID + "/config{/config_setting}",
+		ZonesURL:   "/api/v1/servers/" + s.ServerID + "/zones{/zone}",
+	}
+}
+
+func (s *Server) listServers(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []servers.Server{s.server()})
+}
+
+func (s *Server) getServer(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.server())
+}
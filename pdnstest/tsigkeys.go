@@ -0,0 +1,134 @@
+package pdnstest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mittwald/go-powerdns/apis/tsigkey"
+)
+
+func tsigKeyID(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+func (s *Server) lookupTSIGKey(w http.ResponseWriter, r *http.Request) *tsigkey.TSIGKey {
+	if !s.checkServer(w, r) {
+		return nil
+	}
+
+	id := r.PathValue("id")
+
+	if k, ok := s.tsigKeys[tsigKeyID(id)]; ok {
+		return k
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("TSIG key with name '%s' not found", id))
+	return nil
+}
+
+func (s *Server) listTSIGKeys(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	out := make([]tsigkey.TSIGKey, 0, len(s.tsigKeys))
+
+	for _, k := range s.tsigKeys {
+		c := *k
+		c.Key = ""
+		out = append(out, c)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createTSIGKey(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	in := tsigkey.TSIGKey{}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if in.Name == "" || in.Algorithm == "" {
+		writeError(w, http.StatusUnprocessableEntity, "TSIG key name and algorithm must be set")
+		return
+	}
+
+	id := tsigKeyID(in.Name)
+	if _, ok := s.tsigKeys[id]; ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("A TSIG key with the name '%s' already exists", in.Name))
+		return
+	}
+
+	key := in
+	key.ID = id
+	key.Type = "TSIGKey"
+
+	if key.Key == "" {
+		key.Key = randomBase64(32)
+	}
+
+	s.tsigKeys[id] = &key
+	writeJSON(w, http.StatusCreated, key)
+}
+
+func (s *Server) getTSIGKey(w http.ResponseWriter, r *http.Request) {
+	k := s.lookupTSIGKey(w, r)
+	if k == nil {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, k)
+}
+
+func (s *Server) updateTSIGKey(w http.ResponseWriter, r *http.Request) {
+	k := s.lookupTSIGKey(w, r)
+	if k == nil {
+		return
+	}
+
+	in := tsigkey.TSIGKey{}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	updated := *k
+
+	if in.Algorithm != "" {
+		updated.Algorithm = in.Algorithm
+	}
+
+	if in.Key != "" {
+		updated.Key = in.Key
+	}
+
+	if in.Name != "" && tsigKeyID(in.Name) != k.ID {
+		if _, ok := s.tsigKeys[tsigKeyID(in.Name)]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("A TSIG key with the name '%s' already exists", in.Name))
+			return
+		}
+
+		delete(s.tsigKeys, k.ID)
+		updated.Name = in.Name
+		updated.ID = tsigKeyID(in.Name)
+	}
+
+	s.tsigKeys[updated.ID] = &updated
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deleteTSIGKey(w http.ResponseWriter, r *http.Request) {
+	k := s.lookupTSIGKey(w, r)
+	if k == nil {
+		return
+	}
+
+	delete(s.tsigKeys, k.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
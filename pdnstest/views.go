@@ -0,0 +1,94 @@
+package pdnstest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/mittwald/go-powerdns/apis/views"
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+func (s *Server) listViews(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	out := views.ViewsList{Views: make([]string, 0, len(s.views))}
+
+	for name := range s.views {
+		out.Views = append(out.Views, name)
+	}
+
+	sort.Strings(out.Views)
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) listViewZones(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	members, ok := s.views[r.PathValue("view")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("View '%s' does not exist", r.PathValue("view")))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, views.ZoneList{Zones: append([]string{}, members...)})
+}
+
+func (s *Server) addZoneToView(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	in := struct {
+		Name string `json:"name"`
+	}{}
+
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	z, ok := s.zones[in.Name]
+	if !ok {
+		z, ok = s.zones[dnsname.Normalize(in.Name)]
+	}
+
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find domain '%s'", in.Name))
+		return
+	}
+
+	view := r.PathValue("view")
+	if !containsString(s.views[view], z.zone.Name) {
+		s.views[view] = append(s.views[view], z.zone.Name)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeZoneFromView(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	view := r.PathValue("view")
+	members, ok := s.views[view]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("View '%s' does not exist", view))
+		return
+	}
+
+	id := r.PathValue("id")
+	members = removeString(removeString(members, id), dnsname.Normalize(id))
+
+	if len(members) == 0 {
+		delete(s.views, view)
+	} else {
+		s.views[view] = members
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,680 @@
+package pdnstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/dnsname"
+)
+
+// rrsetPatch models a single rrset of a PATCH request. Records and comments
+// are kept raw, because PowerDNS only replaces them if they are present (and
+// not null) in the request body.
+type rrsetPatch struct {
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	TTL        int             `json:"ttl"`
+	ChangeType string          `json:"changetype"`
+	Records    json.RawMessage `json:"records"`
+	Comments   json.RawMessage `json:"comments"`
+}
+
+func isJSONArray(raw json.RawMessage) bool {
+	return len(bytes.TrimSpace(raw)) > 0 && bytes.TrimSpace(raw)[0] == '['
+}
+
+// canonical returns the canonical form of an absolute name. Relative names,
+// which PowerDNS rejects, are only lowercased, so that validation can still
+// report them.
+func canonical(name string) string {
+	if !dnsname.IsAbsolute(name) {
+		return strings.ToLower(name)
+	}
+
+	return dnsname.Normalize(name)
+}
+
+func copyZone(z zones.Zone) zones.Zone {
+	out := z
+	out.ResourceRecordSets = make([]zones.ResourceRecordSet, len(z.ResourceRecordSets))
+
+	for i, set := range z.ResourceRecordSets {
+		set.Records = append([]zones.Record{}, set.Records...)
+		set.Comments = append([]zones.Comment{}, set.Comments...)
+		out.ResourceRecordSets[i] = set
+	}
+
+	out.Masters = append([]string(nil), z.Masters...)
+	return out
+}
+
+func sortRecordSets(sets []zones.ResourceRecordSet) {
+	sort.SliceStable(sets, func(i, j int) bool {
+		if sets[i].Name != sets[j].Name {
+			return sets[i].Name < sets[j].Name
+		}
+		return sets[i].Type < sets[j].Type
+	})
+}
+
+func findRecordSet(sets []zones.ResourceRecordSet, name, recordType string) int {
+	for i := range sets {
+		if sets[i].Name == name && sets[i].Type == recordType {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// lookupZone returns the zone addressed by the request, or writes an error
+// response and returns nil.
+func (s *Server) lookupZone(w http.ResponseWriter, r *http.Request) *zoneState {
+	if !s.checkServer(w, r) {
+		return nil
+	}
+
+	id := r.PathValue("zone")
+
+	if z, ok := s.zones[id]; ok {
+		return z
+	}
+
+	if z, ok := s.zones[dnsname.Normalize(id)]; ok {
+		return z
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find domain '%s'", id))
+	return nil
+}
+
+// setSerial sets a zone's serial, and updates its SOA record accordingly.
+func setSerial(z *zones.Zone, serial int) {
+	z.Serial = serial
+	z.EditedSerial = serial
+
+	idx := findRecordSet(z.ResourceRecordSets, z.Name, "SOA")
+	if idx < 0 {
+		return
+	}
+
+	for i := range z.ResourceRecordSets[idx].Records {
+		fields := strings.Fields(z.ResourceRecordSets[idx].Records[i].Content)
+		if len(fields) == 7 {
+			fields[2] = strconv.Itoa(serial)
+			z.ResourceRecordSets[idx].Records[i].Content = strings.Join(fields, " ")
+		}
+	}
+}
+
+// soaSerial returns the serial contained in a zone's SOA record.
+func soaSerial(z *zones.Zone) (int, bool) {
+	idx := findRecordSet(z.ResourceRecordSets, z.Name, "SOA")
+	if idx < 0 || len(z.ResourceRecordSets[idx].Records) == 0 {
+		return 0, false
+	}
+
+	fields := strings.Fields(z.ResourceRecordSets[idx].Records[0].Content)
+	if len(fields) != 7 {
+		return 0, false
+	}
+
+	serial, err := strconv.Atoi(fields[2])
+	return serial, err == nil
+}
+
+// validateRecordSet checks a record set for errors that PowerDNS would
+// reject with a 422 status.
+func validateRecordSet(zone string, set *zones.ResourceRecordSet) error {
+	if !dnsname.IsAbsolute(set.Name) {
+		return fmt.Errorf("RRset %s IN %s: Name is not canonical", set.Name, set.Type)
+	}
+
+	if !dnsname.IsSubdomain(set.Name, zone) {
+		return fmt.Errorf("RRset %s IN %s: Name is out of zone", set.Name, set.Type)
+	}
+
+	if set.Type == "" {
+		return fmt.Errorf("RRset %s: Type is missing", set.Name)
+	}
+
+	seen := map[string]struct{}{}
+	for _, rec := range set.Records {
+		if rec.Content == "" {
+			return fmt.Errorf("RRset %s IN %s: Record content is empty", set.Name, set.Type)
+		}
+
+		if _, ok := seen[rec.Content]; ok {
+			return fmt.Errorf("RRset %s IN %s: Duplicate record in RRset", set.Name, set.Type)
+		}
+
+		seen[rec.Content] = struct{}{}
+	}
+
+	if set.Type == "CNAME" && len(set.Records) > 1 {
+		return fmt.Errorf("RRset %s IN CNAME: Only one record allowed", set.Name)
+	}
+
+	return nil
+}
+
+// validateCNAMEConflicts checks that no name has both a CNAME and other
+// record sets.
+func validateCNAMEConflicts(sets []zones.ResourceRecordSet) error {
+	types := map[string][]string{}
+
+	for _, set := range sets {
+		types[set.Name] = append(types[set.Name], set.Type)
+	}
+
+	for name, t := range types {
+		if len(t) < 2 {
+			continue
+		}
+
+		for _, typ := range t {
+			if typ == "CNAME" {
+				return fmt.Errorf("RRset %s IN CNAME: Conflicts with pre-existing RRset", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	filter := r.URL.Query().Get("zone")
+	out := make([]zones.Zone, 0, len(s.zones))
+
+	for _, z := range s.zones {
+		if filter != "" && !dnsname.Equal(z.zone.Name, filter) {
+			continue
+		}
+
+		c := copyZone(z.zone)
+		c.ResourceRecordSets = nil
+
+		if r.URL.Query().Get("dnssec") == "false" {
+			c.DNSSec = false
+			c.EditedSerial = 0
+		}
+
+		out = append(out, c)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	in := zones.Zone{}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	name := canonical(in.Name)
+	if name == "" || !dnsname.IsAbsolute(name) {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("DNS Name '%s' is not canonical", in.Name))
+		return
+	}
+
+	if _, ok := s.zones[name]; ok {
+		writeError(w, http.StatusConflict, "Conflict")
+		return
+	}
+
+	z := in
+	z.ID = name
+	z.Name = name
+	z.Type = zones.ZoneTypeZone
+	z.URL = fmt.Sprintf("/api/v1/servers/%s/zones/%s", s.ServerID, name)
+	z.Nameservers = nil
+
+	if z.Kind == 0 {
+		z.Kind = zones.ZoneKindNative
+	}
+
+	if z.SOAEditAPI == 0 {
+		z.SOAEditAPI = zones.ZoneSOAEditAPIDefault
+	}
+
+	z.ResourceRecordSets = make([]zones.ResourceRecordSet, 0, len(in.ResourceRecordSets)+2)
+
+	for _, set := range in.ResourceRecordSets {
+		set.Name = canonical(set.Name)
+		set.ChangeType = 0
+
+		if err := validateRecordSet(name, &set); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		if findRecordSet(z.ResourceRecordSets, set.Name, set.Type) >= 0 {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("RRset %s IN %s: Duplicate RRset", set.Name, set.Type))
+			return
+		}
+
+		if set.Comments == nil {
+			set.Comments = []zones.Comment{}
+		}
+
+		z.ResourceRecordSets = append(z.ResourceRecordSets, set)
+	}
+
+	if findRecordSet(z.ResourceRecordSets, name, "NS") >= 0 && len(in.Nameservers) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "Nameservers list MUST NOT be mixed with zone-level NS in rrsets")
+		return
+	}
+
+	if len(in.Nameservers) > 0 {
+		ns := zones.ResourceRecordSet{Name: name, Type: "NS", TTL: 3600, Comments: []zones.Comment{}}
+		for _, n := range in.Nameservers {
+			ns.Records = append(ns.Records, zones.Record{Content: canonical(n)})
+		}
+
+		z.ResourceRecordSets = append(z.ResourceRecordSets, ns)
+	}
+
+	if err := validateCNAMEConflicts(z.ResourceRecordSets); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	serial, ok := soaSerial(&z)
+	if !ok {
+		serial, _ = strconv.Atoi(time.Now().UTC().Format("20060102") + "01")
+		z.ResourceRecordSets = append(z.ResourceRecordSets, zones.ResourceRecordSet{
+			Name: name,
+			Type: "SOA",
+			TTL:  3600,
+			Records: []zones.Record{{
+				Content: fmt.Sprintf("a.misconfigured.dns.server.invalid. hostmaster.%s %d 10800 3600 604800 3600", name, serial),
+			}},
+			Comments: []zones.Comment{},
+		})
+	}
+
+	sortRecordSets(z.ResourceRecordSets)
+	setSerial(&z, serial)
+	z.EditedSerial = serial
+
+	s.zones[name] = &zoneState{zone: z}
+
+	writeJSON(w, http.StatusCreated, copyZone(z))
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	out := copyZone(z.zone)
+	q := r.URL.Query()
+
+	if q.Get("rrsets") == "false" {
+		out.ResourceRecordSets = nil
+	} else if name := q.Get("rrset_name"); name != "" {
+		filtered := make([]zones.ResourceRecordSet, 0)
+
+		for _, set := range out.ResourceRecordSets {
+			if !dnsname.Equal(set.Name, name) {
+				continue
+			}
+
+			if t := q.Get("rrset_type"); t != "" && set.Type != t {
+				continue
+			}
+
+			filtered = append(filtered, set)
+		}
+
+		out.ResourceRecordSets = filtered
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) patchZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	in := struct {
+		RecordSets []rrsetPatch `json:"rrsets"`
+	}{}
+
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	// apply all changes on a copy first, so that the zone remains unchanged
+	// if any of them is invalid
+	updated := copyZone(z.zone)
+
+	for _, p := range in.RecordSets {
+		if err := applyPatch(&updated, p); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+	}
+
+	if err := validateCNAMEConflicts(updated.ResourceRecordSets); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	sortRecordSets(updated.ResourceRecordSets)
+	setSerial(&updated, updated.Serial+1)
+
+	z.zone = updated
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func applyPatch(z *zones.Zone, p rrsetPatch) error {
+	set := zones.ResourceRecordSet{
+		Name: canonical(p.Name),
+		Type: p.Type,
+		TTL:  p.TTL,
+	}
+
+	idx := findRecordSet(z.ResourceRecordSets, set.Name, set.Type)
+
+	switch p.ChangeType {
+	case "DELETE":
+		if !dnsname.IsAbsolute(set.Name) || !dnsname.IsSubdomain(set.Name, z.Name) {
+			return fmt.Errorf("RRset %s IN %s: Name is out of zone", set.Name, set.Type)
+		}
+
+		if idx >= 0 {
+			z.ResourceRecordSets = append(z.ResourceRecordSets[:idx], z.ResourceRecordSets[idx+1:]...)
+		}
+
+		return nil
+
+	case "REPLACE":
+		if idx >= 0 {
+			existing := z.ResourceRecordSets[idx]
+			set.Records = existing.Records
+			set.Comments = existing.Comments
+
+			if set.TTL == 0 {
+				set.TTL = existing.TTL
+			}
+		}
+
+		if isJSONArray(p.Records) {
+			set.Records = []zones.Record{}
+			if err := json.Unmarshal(p.Records, &set.Records); err != nil {
+				return err
+			}
+		}
+
+		if isJSONArray(p.Comments) {
+			set.Comments = []zones.Comment{}
+			if err := json.Unmarshal(p.Comments, &set.Comments); err != nil {
+				return err
+			}
+
+			for i := range set.Comments {
+				if set.Comments[i].ModifiedAt.IsZero() {
+					set.Comments[i].ModifiedAt = time.Now().Truncate(time.Second)
+				}
+			}
+		}
+
+		if set.Comments == nil {
+			set.Comments = []zones.Comment{}
+		}
+
+		if err := validateRecordSet(z.Name, &set); err != nil {
+			return err
+		}
+
+		if len(set.Records) > 0 && set.TTL <= 0 {
+			return fmt.Errorf("RRset %s IN %s: TTL must be set", set.Name, set.Type)
+		}
+
+		switch {
+		case len(set.Records) == 0 && len(set.Comments) == 0:
+			if idx >= 0 {
+				z.ResourceRecordSets = append(z.ResourceRecordSets[:idx], z.ResourceRecordSets[idx+1:]...)
+			}
+		case idx >= 0:
+			z.ResourceRecordSets[idx] = set
+		default:
+			z.ResourceRecordSets = append(z.ResourceRecordSets, set)
+		}
+
+		return nil
+
+	case "EXTEND", "PRUNE":
+		records := []zones.Record{}
+		if isJSONArray(p.Records) {
+			if err := json.Unmarshal(p.Records, &records); err != nil {
+				return err
+			}
+		}
+
+		if idx >= 0 {
+			set = z.ResourceRecordSets[idx]
+		} else {
+			set.Comments = []zones.Comment{}
+		}
+
+		if p.ChangeType == "EXTEND" {
+			if p.TTL > 0 {
+				set.TTL = p.TTL
+			}
+
+			for _, rec := range records {
+				if !containsRecord(set.Records, rec.Content) {
+					set.Records = append(set.Records, rec)
+				}
+			}
+		} else {
+			remaining := make([]zones.Record, 0, len(set.Records))
+			for _, rec := range set.Records {
+				if !containsRecord(records, rec.Content) {
+					remaining = append(remaining, rec)
+				}
+			}
+
+			set.Records = remaining
+		}
+
+		if err := validateRecordSet(z.Name, &set); err != nil {
+			return err
+		}
+
+		switch {
+		case len(set.Records) == 0:
+			if idx >= 0 {
+				z.ResourceRecordSets = append(z.ResourceRecordSets[:idx], z.ResourceRecordSets[idx+1:]...)
+			}
+		case set.TTL <= 0:
+			return fmt.Errorf("RRset %s IN %s: TTL must be set", set.Name, set.Type)
+		case idx >= 0:
+			z.ResourceRecordSets[idx] = set
+		default:
+			z.ResourceRecordSets = append(z.ResourceRecordSets, set)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("Changetype not understood")
+}
+
+func (s *Server) modifyZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	// the account is decoded separately, since an empty account (unlike an
+	// absent one) clears it
+	in := struct {
+		zones.ZoneBasicDataUpdate
+		Account *string `json:"account"`
+	}{}
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	if in.Kind != 0 {
+		z.zone.Kind = in.Kind
+	}
+
+	if in.Masters != nil {
+		z.zone.Masters = in.Masters
+	}
+
+	if in.Account != nil {
+		z.zone.Account = *in.Account
+	}
+
+	if in.SOAEdit != 0 {
+		z.zone.SOAEdit = in.SOAEdit
+	}
+
+	if in.SOAEditAPI != 0 {
+		z.zone.SOAEditAPI = in.SOAEditAPI
+	}
+
+	if in.APIRectify != nil {
+		z.zone.APIRectify = *in.APIRectify
+	}
+
+	if in.DNSSec != nil {
+		z.zone.DNSSec = *in.DNSSec
+	}
+
+	if in.NSec3Param != "" {
+		z.zone.NSec3Param = in.NSec3Param
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	delete(s.zones, z.zone.ID)
+
+	for view, members := range s.views {
+		s.views[view] = removeString(members, z.zone.Name)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) exportZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	buf := bytes.Buffer{}
+
+	for _, set := range z.zone.ResourceRecordSets {
+		for _, rec := range set.Records {
+			if rec.Disabled {
+				continue
+			}
+
+			fmt.Fprintf(&buf, "%s\t%d\tIN\t%s\t%s\n", set.Name, set.TTL, set.Type, rec.Content)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=us-ascii")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (s *Server) notifyZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"result": "Notification queued"})
+}
+
+func (s *Server) retrieveZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	if z.zone.Kind != zones.ZoneKindSlave && z.zone.Kind != zones.ZoneKindConsumer {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Domain '%s' is not a secondary domain", z.zone.Name))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"result": fmt.Sprintf("Added retrieval request for '%s' from primary", z.zone.Name)})
+}
+
+func (s *Server) rectifyZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	if z.zone.Presigned {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Zone '%s' is a presigned zone, not rectifying.", z.zone.Name))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"result": "Rectified"})
+}
+
+func (s *Server) checkZone(w http.ResponseWriter, r *http.Request) {
+	z := s.lookupZone(w, r)
+	if z == nil {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"result": "Zone is valid"})
+}
+
+func containsRecord(records []zones.Record, content string) bool {
+	for _, r := range records {
+		if r.Content == content {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	out := list[:0]
+
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,164 @@
+package pdns
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mittwald/go-powerdns/apis/zones"
+)
+
+// DefaultPropagationPollInterval is the default interval in which
+// WaitForPropagation polls the secondaries.
+const DefaultPropagationPollInterval = time.Second
+
+// PropagationTarget is a PowerDNS server that is polled by WaitForPropagation.
+type PropagationTarget struct {
+	// Name identifies the server in the results; for example, its host name.
+	Name string
+
+	// Client is the API client for this server.
+	Client Client
+
+	// ServerID is the server ID within the API; usually "localhost".
+	ServerID string
+}
+
+// PropagationStatus is the state of a zone on a single server.
+type PropagationStatus struct {
+	Name string
+
+	// Serial is the zone serial last retrieved from this server.
+	Serial zones.Serial
+
+	// UpToDate is true if the server has caught up with the primary.
+	UpToDate bool
+
+	// Err is the error of the last attempt to retrieve the zone, if any.
+	Err error
+}
+
+// PropagationResult is the result of WaitForPropagation.
+type PropagationResult struct {
+	// Serial is the serial of the primary that was waited for.
+	Serial zones.Serial
+
+	// Secondaries contains the status of each secondary, in the same order in
+	// which the secondaries were passed to WaitForPropagation.
+	Secondaries []PropagationStatus
+}
+
+// UpToDate returns true if all secondaries have caught up with the primary.
+func (r *PropagationResult) UpToDate() bool {
+	for i := range r.Secondaries {
+		if !r.Secondaries[i].UpToDate {
+			return false
+		}
+	}
+
+	return true
+}
+
+type propagationConfig struct {
+	interval time.Duration
+}
+
+// PropagationOption configures WaitForPropagation.
+type PropagationOption func(c *propagationConfig)
+
+// WithPollInterval sets the interval in which WaitForPropagation polls the
+// secondaries; the default is DefaultPropagationPollInterval. Intervals that
+// are not positive are ignored.
+func WithPollInterval(d time.Duration) PropagationOption {
+	return func(c *propagationConfig) {
+		if d > 0 {
+			c.interval = d
+		}
+	}
+}
+
+// WaitForPropagation blocks until all secondaries serve a zone with (at least)
+// the serial that the primary currently has, or until ctx expires. Serials are
+// compared using serial number arithmetic (see zones.Serial), so secondaries
+// that are already ahead of the primary are considered to have caught up.
+//
+// The serial of the primary is retrieved once; failing to do so is an error.
+// Errors while polling secondaries (for example, because the zone was not yet
+// transferred to a new secondary) are recorded in their status, and polling
+// continues.
+//
+// The result is returned even if ctx expires; in this case, the error wraps
+// the context's error, and the result shows which secondaries lag behind.
+func WaitForPropagation(ctx context.Context, zoneID string, primary PropagationTarget, secondaries []PropagationTarget, opts ...PropagationOption) (*PropagationResult, error) {
+	cfg := propagationConfig{interval: DefaultPropagationPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	zone, err := primary.Client.Zones().GetZone(ctx, primary.ServerID, zoneID, zones.WithoutResourceRecordSets())
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve zone %s from primary %s: %w", zoneID, primary.Name, err)
+	}
+
+	result := PropagationResult{
+		Serial:      zone.SerialNumber(),
+		Secondaries: make([]PropagationStatus, len(secondaries)),
+	}
+
+	for i := range secondaries {
+		result.Secondaries[i].Name = secondaries[i].Name
+	}
+
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		pollSecondaries(ctx, zoneID, secondaries, &result)
+
+		if result.UpToDate() {
+			return &result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &result, fmt.Errorf("zone %s was not propagated to all secondaries: %w", zoneID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollSecondaries retrieves the zone from all secondaries that have not yet
+// caught up, concurrently, and updates their status.
+func pollSecondaries(ctx context.Context, zoneID string, secondaries []PropagationTarget, result *PropagationResult) {
+	wg := sync.WaitGroup{}
+
+	for i := range secondaries {
+		status := &result.Secondaries[i]
+		if status.UpToDate {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(target PropagationTarget) {
+			defer wg.Done()
+
+			zone, err := target.Client.Zones().GetZone(ctx, target.ServerID, zoneID, zones.WithoutResourceRecordSets())
+			if err != nil {
+				// keep the status of the previous attempt if the request was
+				// only aborted because ctx expired
+				if ctx.Err() == nil {
+					status.Err = err
+				}
+				return
+			}
+
+			status.Err = nil
+			status.Serial = zone.SerialNumber()
+			status.UpToDate = !status.Serial.Less(result.Serial)
+		}(secondaries[i])
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,104 @@
+package pdns_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/pdnstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func propagationTarget(t *testing.T, name string) pdns.PropagationTarget {
+	srv := pdnstest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := srv.NewClient()
+	require.Nil(t, err)
+
+	_, err = c.Zones().CreateZone(context.Background(), "localhost", zones.Zone{
+		Name:        "example.com.",
+		Kind:        zones.ZoneKindNative,
+		Nameservers: []string{"ns1.example.com."},
+	})
+	require.Nil(t, err)
+
+	return pdns.PropagationTarget{Name: name, Client: c, ServerID: "localhost"}
+}
+
+func bumpSerial(t *testing.T, target pdns.PropagationTarget) {
+	err := target.Client.Zones().AddRecordSetToZone(context.Background(), "localhost", "example.com.", zones.ResourceRecordSet{
+		Name: "www.example.com.", Type: "A", TTL: 60, Records: []zones.Record{{Content: "192.0.2.1"}},
+	})
+	assert.Nil(t, err)
+}
+
+func TestWaitForPropagationWaitsForSecondaries(t *testing.T) {
+	primary := propagationTarget(t, "primary")
+	upToDate := propagationTarget(t, "ns1")
+	lagging := propagationTarget(t, "ns2")
+
+	bumpSerial(t, primary)
+	bumpSerial(t, upToDate)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		bumpSerial(t, lagging)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := pdns.WaitForPropagation(ctx, "example.com.", primary, []pdns.PropagationTarget{upToDate, lagging}, pdns.WithPollInterval(10*time.Millisecond))
+
+	require.Nil(t, err)
+	assert.True(t, result.UpToDate())
+	require.Len(t, result.Secondaries, 2)
+	assert.Equal(t, "ns1", result.Secondaries[0].Name)
+	assert.Equal(t, result.Serial, result.Secondaries[0].Serial)
+	assert.Equal(t, result.Serial, result.Secondaries[1].Serial)
+}
+
+func TestWaitForPropagationReturnsStatusOnTimeout(t *testing.T) {
+	primary := propagationTarget(t, "primary")
+	lagging := propagationTarget(t, "ns1")
+	missing := pdns.PropagationTarget{Name: "ns2", Client: primary.Client, ServerID: "unknown"}
+
+	bumpSerial(t, primary)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	result, err := pdns.WaitForPropagation(ctx, "example.com.", primary, []pdns.PropagationTarget{lagging, missing}, pdns.WithPollInterval(10*time.Millisecond))
+
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.NotNil(t, result)
+	assert.False(t, result.UpToDate())
+	assert.False(t, result.Secondaries[0].UpToDate)
+	assert.True(t, result.Secondaries[0].Serial.Less(result.Serial))
+	assert.Nil(t, result.Secondaries[0].Err)
+	assert.NotNil(t, result.Secondaries[1].Err)
+}
+
+func TestWaitForPropagationFailsWithoutPrimaryZone(t *testing.T) {
+	primary := propagationTarget(t, "primary")
+
+	_, err := pdns.WaitForPropagation(context.Background(), "unknown.example.", primary, nil)
+
+	require.NotNil(t, err)
+}
+
+func TestWaitForPropagationIgnoresNonPositivePollInterval(t *testing.T) {
+	primary := propagationTarget(t, "primary")
+	secondary := propagationTarget(t, "ns1")
+
+	result, err := pdns.WaitForPropagation(context.Background(), "example.com.", primary, []pdns.PropagationTarget{secondary}, pdns.WithPollInterval(0))
+
+	require.Nil(t, err)
+	assert.True(t, result.UpToDate())
+}